@@ -72,7 +72,7 @@ func main() {
 			statusColor = green
 		}
 		printKV("Market", fmt.Sprintf("%s%s%s", statusColor, status.IsOpen, reset))
-		printKV("As Of", status.AsOf)
+		printKV("As Of", status.AsOf.String())
 	}
 
 	// Market Summary
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/itsbohara/go-nepse"
+	"github.com/itsbohara/go-nepse/npr"
 )
 
 type app struct {
@@ -57,18 +58,34 @@ func main() {
 func (a *app) handleMarketSummary(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
-	s, err := a.client.GetMarketSummary(ctx)
+	s, err := a.client.MarketSummary(ctx)
 	if err != nil {
 		writeErr(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, s)
+	if r.URL.Query().Get("format") != "npr" {
+		writeJSON(w, http.StatusOK, s)
+		return
+	}
+
+	// Amounts as Nepali readers expect them, e.g. "Rs. 4.52 Arab" or "रु. ४.५२ अर्ब".
+	devanagari := r.URL.Query().Get("lang") == "ne"
+	amount := &npr.Options{Decimals: 2, Unit: npr.Auto, Currency: true, Devanagari: devanagari}
+	count := &npr.Options{Devanagari: devanagari}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"totalTurnover":             npr.Format(s.TotalTurnover, amount),
+		"totalTradedShares":         npr.Format(s.TotalTradedShares, count),
+		"totalTransactions":         npr.Format(s.TotalTransactions, count),
+		"totalScripsTraded":         npr.Format(s.TotalScripsTraded, count),
+		"totalMarketCapitalization": npr.Format(s.TotalMarketCapitalization, amount),
+		"totalFloatMarketCap":       npr.Format(s.TotalFloatMarketCap, amount),
+	})
 }
 
 func (a *app) handleMarketStatus(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
-	s, err := a.client.GetMarketStatus(ctx)
+	s, err := a.client.MarketStatus(ctx)
 	if err != nil {
 		writeErr(w, err)
 		return
@@ -79,7 +96,7 @@ func (a *app) handleMarketStatus(w http.ResponseWriter, r *http.Request) {
 func (a *app) handleTopGainers(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
-	v, err := a.client.GetTopGainers(ctx)
+	v, err := a.client.TopGainers(ctx)
 	if err != nil {
 		writeErr(w, err)
 		return
@@ -115,7 +132,7 @@ func (a *app) handleSecurityRoutes(w http.ResponseWriter, r *http.Request) {
 func (a *app) handleCompanyBySymbol(w http.ResponseWriter, r *http.Request, symbol string) {
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
-	d, err := a.client.GetCompanyDetailsBySymbol(ctx, symbol)
+	d, err := a.client.CompanyBySymbol(ctx, symbol)
 	if err != nil {
 		writeErr(w, err)
 		return
@@ -126,7 +143,7 @@ func (a *app) handleCompanyBySymbol(w http.ResponseWriter, r *http.Request, symb
 func (a *app) handleDepthBySymbol(w http.ResponseWriter, r *http.Request, symbol string) {
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
-	d, err := a.client.GetMarketDepthBySymbol(ctx, symbol)
+	d, err := a.client.MarketDepthBySymbol(ctx, symbol)
 	if err != nil {
 		writeErr(w, err)
 		return
@@ -144,7 +161,7 @@ func (a *app) handleHistoryBySymbol(w http.ResponseWriter, r *http.Request, symb
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 45*time.Second)
 	defer cancel()
-	h, err := a.client.GetPriceVolumeHistoryBySymbol(ctx, symbol, start, end)
+	h, err := a.client.PriceHistoryBySymbol(ctx, symbol, start, end)
 	if err != nil {
 		writeErr(w, err)
 		return
@@ -224,7 +241,14 @@ const openapiJSON = `{
       "get": {"summary": "Health", "responses": {"200": {"description": "ok"}}}
     },
     "/test/market/summary": {
-      "get": {"summary": "Market summary", "responses": {"200": {"description": "OK"}}}
+      "get": {
+        "summary": "Market summary",
+        "parameters": [
+          {"name":"format","in":"query","required":false,"schema":{"type":"string","enum":["npr"]},"description":"npr formats amounts in lakh/crore/arab"},
+          {"name":"lang","in":"query","required":false,"schema":{"type":"string","enum":["en","ne"]},"description":"ne uses Devanagari numerals"}
+        ],
+        "responses": {"200": {"description": "OK"}}
+      }
     },
     "/test/market/status": {
       "get": {"summary": "Market status", "responses": {"200": {"description": "OK"}}}
@@ -0,0 +1,284 @@
+// Package bs converts between Bikram Sambat (BS), the official calendar of
+// Nepal, and the Gregorian (AD) calendar, and maps dates to Nepal's fiscal
+// years and quarters.
+//
+// Conversion is table driven and supports BS 2000-01-01 (AD 1943-04-14)
+// through the end of BS 2090. Dates are calendar days; AD dates are taken as
+// the calendar day of the given [time.Time] in its own location, and returned
+// at midnight Nepal time.
+package bs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/itsbohara/go-nepse/internal/npt"
+)
+
+const (
+	firstYear = 2000
+	lastYear  = firstYear + len(monthDays) - 1
+)
+
+// ErrOutOfRange is returned for dates outside the supported BS 2000–2090 range.
+var ErrOutOfRange = errors.New("bs: date outside supported range")
+
+// epoch is the AD day of BS 2000-01-01.
+var epoch = time.Date(1943, time.April, 14, 0, 0, 0, 0, time.UTC)
+
+// yearStart[i] is the number of days from epoch to the first day of BS year firstYear+i.
+var yearStart = func() []int {
+	starts := make([]int, len(monthDays)+1)
+	for i, months := range monthDays {
+		n := 0
+		for _, d := range months {
+			n += int(d)
+		}
+		starts[i+1] = starts[i] + n
+	}
+	return starts
+}()
+
+// Month is a Bikram Sambat month, starting with Baisakh = 1.
+type Month int
+
+const (
+	Baisakh Month = iota + 1
+	Jestha
+	Ashadh
+	Shrawan
+	Bhadra
+	Ashwin
+	Kartik
+	Mangsir
+	Poush
+	Magh
+	Falgun
+	Chaitra
+)
+
+var monthNames = [...]string{"Baisakh", "Jestha", "Ashadh", "Shrawan", "Bhadra", "Ashwin", "Kartik", "Mangsir", "Poush", "Magh", "Falgun", "Chaitra"}
+
+var monthNamesNepali = [...]string{"बैशाख", "जेठ", "असार", "साउन", "भदौ", "असोज", "कात्तिक", "मंसिर", "पुस", "माघ", "फागुन", "चैत"}
+
+// String returns the month's romanized name, e.g. "Baisakh".
+func (m Month) String() string {
+	if m < Baisakh || m > Chaitra {
+		return "Month(" + strconv.Itoa(int(m)) + ")"
+	}
+	return monthNames[m-1]
+}
+
+// Nepali returns the month's name in Devanagari, e.g. "बैशाख".
+func (m Month) Nepali() string {
+	if m < Baisakh || m > Chaitra {
+		return m.String()
+	}
+	return monthNamesNepali[m-1]
+}
+
+// BSDate is a day in the Bikram Sambat calendar. The zero value is not a valid date.
+type BSDate struct {
+	Year  int
+	Month Month
+	Day   int
+}
+
+// New returns the BS date year-month-day, checking that it exists.
+func New(year int, month Month, day int) (BSDate, error) {
+	n, err := DaysInMonth(year, month)
+	if err != nil {
+		return BSDate{}, err
+	}
+	if day < 1 || day > n {
+		return BSDate{}, fmt.Errorf("bs: %s %d has %d days, not %d", month, year, n, day)
+	}
+	return BSDate{Year: year, Month: month, Day: day}, nil
+}
+
+// DaysInMonth returns the number of days in month of BS year.
+func DaysInMonth(year int, month Month) (int, error) {
+	if year < firstYear || year > lastYear {
+		return 0, ErrOutOfRange
+	}
+	if month < Baisakh || month > Chaitra {
+		return 0, fmt.Errorf("bs: invalid month %d", month)
+	}
+	return int(monthDays[year-firstYear][month-1]), nil
+}
+
+// FromAD returns the BS date of t's calendar day.
+func FromAD(t time.Time) (BSDate, error) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := int(day.Sub(epoch).Hours() / 24)
+	if offset < 0 || offset >= yearStart[len(yearStart)-1] {
+		return BSDate{}, ErrOutOfRange
+	}
+
+	i := 0
+	for yearStart[i+1] <= offset {
+		i++
+	}
+	offset -= yearStart[i]
+	for m, n := range monthDays[i] {
+		if offset < int(n) {
+			return BSDate{Year: firstYear + i, Month: Month(m + 1), Day: offset + 1}, nil
+		}
+		offset -= int(n)
+	}
+	panic("unreachable")
+}
+
+// Today returns the current BS date in Nepal. It returns [ErrOutOfRange] once
+// the system clock is past the supported range.
+func Today() (BSDate, error) {
+	return FromAD(time.Now().In(npt.Location))
+}
+
+// ToAD returns the AD date of d at midnight Nepal time.
+func (d BSDate) ToAD() (time.Time, error) {
+	days, err := d.offset()
+	if err != nil {
+		return time.Time{}, err
+	}
+	t := epoch.AddDate(0, 0, days)
+	return npt.Date(t.Year(), t.Month(), t.Day()), nil
+}
+
+// offset returns the number of days from epoch to d.
+func (d BSDate) offset() (int, error) {
+	if _, err := New(d.Year, d.Month, d.Day); err != nil {
+		return 0, err
+	}
+	days := yearStart[d.Year-firstYear] + d.Day - 1
+	for m := Baisakh; m < d.Month; m++ {
+		days += int(monthDays[d.Year-firstYear][m-1])
+	}
+	return days, nil
+}
+
+// AddDays returns d moved by n days.
+func (d BSDate) AddDays(n int) (BSDate, error) {
+	t, err := d.ToAD()
+	if err != nil {
+		return BSDate{}, err
+	}
+	return FromAD(t.AddDate(0, 0, n))
+}
+
+// Weekday returns the day of the week of d, or Sunday for an invalid date.
+func (d BSDate) Weekday() time.Weekday {
+	t, _ := d.ToAD()
+	return t.Weekday()
+}
+
+// Compare returns -1, 0 or +1 as d is before, equal to or after other.
+func (d BSDate) Compare(other BSDate) int {
+	switch {
+	case d.Year != other.Year:
+		return cmpInt(d.Year, other.Year)
+	case d.Month != other.Month:
+		return cmpInt(int(d.Month), int(other.Month))
+	default:
+		return cmpInt(d.Day, other.Day)
+	}
+}
+
+// Before reports whether d is before other.
+func (d BSDate) Before(other BSDate) bool { return d.Compare(other) < 0 }
+
+// After reports whether d is after other.
+func (d BSDate) After(other BSDate) bool { return d.Compare(other) > 0 }
+
+// IsZero reports whether d is the zero value.
+func (d BSDate) IsZero() bool { return d == BSDate{} }
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// String formats d as YYYY-MM-DD in Latin digits, e.g. "2082-06-30".
+func (d BSDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// Devanagari formats d as YYYY-MM-DD in Devanagari digits, e.g. "२०८२-०६-३०".
+func (d BSDate) Devanagari() string {
+	return ToDevanagari(d.String())
+}
+
+// Long formats d with the month name, e.g. "30 Ashwin 2082".
+func (d BSDate) Long() string {
+	return fmt.Sprintf("%d %s %d", d.Day, d.Month, d.Year)
+}
+
+// LongNepali formats d the way Nepali dates are written, e.g. "२०८२ असोज ३०".
+func (d BSDate) LongNepali() string {
+	return ToDevanagari(strconv.Itoa(d.Year)) + " " + d.Month.Nepali() + " " + ToDevanagari(strconv.Itoa(d.Day))
+}
+
+// MarshalText implements [encoding.TextMarshaler] using [BSDate.String].
+func (d BSDate) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler] using [Parse].
+func (d *BSDate) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Parse parses a BS date written as year, month and day separated by '-',
+// '/' or '.', in Latin or Devanagari digits: "2082-06-30", "२०८२/६/३०".
+func Parse(s string) (BSDate, error) {
+	fields := strings.FieldsFunc(ToLatin(strings.TrimSpace(s)), func(r rune) bool {
+		return r == '-' || r == '/' || r == '.'
+	})
+	if len(fields) != 3 {
+		return BSDate{}, fmt.Errorf("bs: invalid date %q, want YYYY-MM-DD", s)
+	}
+
+	var parts [3]int
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return BSDate{}, fmt.Errorf("bs: invalid date %q, want YYYY-MM-DD", s)
+		}
+		parts[i] = n
+	}
+	return New(parts[0], Month(parts[1]), parts[2])
+}
+
+// ToDevanagari replaces the Latin digits in s with Devanagari digits.
+func ToDevanagari(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return '०' + (r - '0')
+		}
+		return r
+	}, s)
+}
+
+// ToLatin replaces the Devanagari digits in s with Latin digits.
+func ToLatin(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '०' && r <= '९' {
+			return '0' + (r - '०')
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,191 @@
+package bs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itsbohara/go-nepse/internal/npt"
+)
+
+func ad(t *testing.T, value string) time.Time {
+	t.Helper()
+	d, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		t.Fatalf("bad test date %q: %v", value, err)
+	}
+	return d
+}
+
+func TestConversion(t *testing.T) {
+	tests := []struct {
+		ad string
+		bs BSDate
+	}{
+		{"1943-04-14", BSDate{2000, Baisakh, 1}},
+		{"2023-04-14", BSDate{2080, Baisakh, 1}},
+		{"2024-04-13", BSDate{2081, Baisakh, 1}},
+		{"2025-04-14", BSDate{2082, Baisakh, 1}},
+		{"2025-09-19", BSDate{2082, Ashwin, 3}},   // Constitution Day
+		{"2026-01-11", BSDate{2082, Poush, 27}},   // Prithvi Jayanti
+		{"2026-02-19", BSDate{2082, Falgun, 7}},   // Democracy Day
+		{"2026-04-13", BSDate{2082, Chaitra, 30}}, // last day of 2082
+		{"2026-04-14", BSDate{2083, Baisakh, 1}},
+		{"2026-05-29", BSDate{2083, Jestha, 15}}, // Republic Day
+	}
+	for _, tt := range tests {
+		got, err := FromAD(ad(t, tt.ad))
+		if err != nil || got != tt.bs {
+			t.Errorf("FromAD(%s) = %v, %v; want %v", tt.ad, got, err, tt.bs)
+		}
+		back, err := tt.bs.ToAD()
+		if err != nil || back.Format(time.DateOnly) != tt.ad {
+			t.Errorf("%v.ToAD() = %v, %v; want %s", tt.bs, back, err, tt.ad)
+		}
+		if back.Location() != npt.Location {
+			t.Errorf("ToAD location = %v, want Nepal time", back.Location())
+		}
+	}
+
+	// Round trip every day of the supported range
+	for day, last := ad(t, "1943-04-14"), ad(t, "2034-04-13"); !day.After(last); day = day.AddDate(0, 0, 1) {
+		d, err := FromAD(day)
+		if err != nil {
+			t.Fatalf("FromAD(%s) failed: %v", day.Format(time.DateOnly), err)
+		}
+		back, err := d.ToAD()
+		if err != nil || back.Format(time.DateOnly) != day.Format(time.DateOnly) {
+			t.Fatalf("round trip of %s via %v gave %v, %v", day.Format(time.DateOnly), d, back, err)
+		}
+	}
+
+	for _, out := range []string{"1943-04-13", "2034-04-14"} {
+		if _, err := FromAD(ad(t, out)); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("FromAD(%s) error = %v, want ErrOutOfRange", out, err)
+		}
+	}
+}
+
+func TestNewValidates(t *testing.T) {
+	if _, err := New(2082, Baisakh, 32); err == nil {
+		t.Error("Baisakh 2082 has 31 days")
+	}
+	if _, err := New(2082, 13, 1); err == nil {
+		t.Error("month 13 should be rejected")
+	}
+	if _, err := New(2091, Baisakh, 1); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("New(2091) error = %v, want ErrOutOfRange", err)
+	}
+}
+
+func TestParseAndFormat(t *testing.T) {
+	want := BSDate{2082, Ashwin, 3}
+	for _, s := range []string{"2082-06-03", "2082/6/3", "2082.06.03", "२०८२-०६-०३", " २०८२/६/३ "} {
+		got, err := Parse(s)
+		if err != nil || got != want {
+			t.Errorf("Parse(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"2082-06", "2082-06-40", "२०८२-x-०३", ""} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) should fail", s)
+		}
+	}
+
+	if got := want.String(); got != "2082-06-03" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := want.Devanagari(); got != "२०८२-०६-०३" {
+		t.Errorf("Devanagari() = %q", got)
+	}
+	if got := want.Long(); got != "3 Ashwin 2082" {
+		t.Errorf("Long() = %q", got)
+	}
+	if got := want.LongNepali(); got != "२०८२ असोज ३" {
+		t.Errorf("LongNepali() = %q", got)
+	}
+	if want.Weekday() != time.Friday {
+		t.Errorf("Weekday() = %v, want Friday", want.Weekday())
+	}
+
+	data, _ := json.Marshal(struct{ Date BSDate }{want})
+	var decoded struct{ Date BSDate }
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Date != want {
+		t.Errorf("JSON round trip of %s = %v, %v", data, decoded.Date, err)
+	}
+}
+
+func TestAddDaysAndCompare(t *testing.T) {
+	d := BSDate{2082, Chaitra, 30}
+	next, err := d.AddDays(1)
+	if err != nil || next != (BSDate{2083, Baisakh, 1}) {
+		t.Errorf("AddDays(1) = %v, %v", next, err)
+	}
+	if !d.Before(next) || !next.After(d) || d.Compare(d) != 0 {
+		t.Error("Compare ordering is wrong")
+	}
+}
+
+func TestToday(t *testing.T) {
+	d, err := Today()
+	if err != nil {
+		t.Fatalf("Today failed: %v", err)
+	}
+	if back, err := d.ToAD(); err != nil || !back.Equal(npt.Day(time.Now())) {
+		t.Errorf("Today() = %v, which is %v AD; want today in Nepal", d, back)
+	}
+}
+
+func TestFiscalYear(t *testing.T) {
+	tests := []struct {
+		date    BSDate
+		fy      FiscalYear
+		quarter Quarter
+	}{
+		{BSDate{2082, Shrawan, 1}, 2082, 1},
+		{BSDate{2082, Ashwin, 30}, 2082, 1},
+		{BSDate{2082, Kartik, 1}, 2082, 2},
+		{BSDate{2082, Poush, 27}, 2082, 2},
+		{BSDate{2082, Magh, 1}, 2082, 3},
+		{BSDate{2082, Chaitra, 30}, 2082, 3},
+		{BSDate{2083, Baisakh, 1}, 2082, 4},
+		{BSDate{2083, Ashadh, 32}, 2082, 4},
+	}
+	for _, tt := range tests {
+		if fy, q := tt.date.FiscalYear(), tt.date.Quarter(); fy != tt.fy || q != tt.quarter {
+			t.Errorf("%v: fiscal period %v %v, want %v %v", tt.date, fy, q, tt.fy, tt.quarter)
+		}
+	}
+
+	fy, q, err := FiscalPeriod("2026-01-05")
+	if err != nil || fy != 2082 || q != 2 {
+		t.Errorf("FiscalPeriod(2026-01-05) = %v %v %v, want 2082/83 Q2", fy, q, err)
+	}
+
+	fy = 2082
+	if fy.String() != "2082/83" || fy.Devanagari() != "२०८२/८३" {
+		t.Errorf("String/Devanagari = %q / %q", fy.String(), fy.Devanagari())
+	}
+	start, end, err := fy.ADRange()
+	if err != nil || start.Format(time.DateOnly) != "2025-07-17" || end.Format(time.DateOnly) != "2026-07-16" {
+		t.Errorf("ADRange = %v - %v, %v", start, end, err)
+	}
+	first, last, err := fy.QuarterRange(4)
+	if err != nil || first != (BSDate{2083, Baisakh, 1}) || last != (BSDate{2083, Ashadh, 32}) {
+		t.Errorf("QuarterRange(4) = %v - %v, %v", first, last, err)
+	}
+}
+
+func TestParseFiscalYear(t *testing.T) {
+	for _, s := range []string{"2082/83", "2082/2083", "2082-2083", "२०८२/८३", "2082"} {
+		if fy, err := ParseFiscalYear(s); err != nil || fy != 2082 {
+			t.Errorf("ParseFiscalYear(%q) = %v, %v", s, fy, err)
+		}
+	}
+	for _, s := range []string{"2082/84", "2082/2084", "82/83", "FY"} {
+		if _, err := ParseFiscalYear(s); err == nil {
+			t.Errorf("ParseFiscalYear(%q) should fail", s)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package bs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FiscalYear is a Nepali fiscal year, identified by the BS year it starts in.
+// It runs from 1 Shrawan to the last day of Ashadh, so FiscalYear(2082) is
+// "2082/83", from 2082-04-01 to 2083-03-end.
+type FiscalYear int
+
+// Quarter is a quarter of a fiscal year, 1 through 4. Q1 is Shrawan–Ashwin,
+// Q2 Kartik–Poush, Q3 Magh–Chaitra and Q4 Baisakh–Ashadh.
+type Quarter int
+
+// String returns the quarter as "Q1" through "Q4".
+func (q Quarter) String() string {
+	return "Q" + strconv.Itoa(int(q))
+}
+
+// FiscalYear returns the fiscal year d falls in.
+func (d BSDate) FiscalYear() FiscalYear {
+	if d.Month >= Shrawan {
+		return FiscalYear(d.Year)
+	}
+	return FiscalYear(d.Year - 1)
+}
+
+// Quarter returns the fiscal quarter d falls in.
+func (d BSDate) Quarter() Quarter {
+	// Shrawan (4) starts Q1; months are shifted so Shrawan maps to 0.
+	return Quarter((int(d.Month)+8)%12/3 + 1)
+}
+
+// FiscalPeriod returns the fiscal year and quarter of an AD date such as
+// NEPSE's BusinessDate ("2026-01-05").
+func FiscalPeriod(businessDate string) (FiscalYear, Quarter, error) {
+	t, err := time.Parse(time.DateOnly, strings.TrimSpace(businessDate))
+	if err != nil {
+		return 0, 0, fmt.Errorf("bs: invalid business date %q, want YYYY-MM-DD", businessDate)
+	}
+	d, err := FromAD(t)
+	if err != nil {
+		return 0, 0, err
+	}
+	return d.FiscalYear(), d.Quarter(), nil
+}
+
+// String returns the fiscal year as "2082/83".
+func (fy FiscalYear) String() string {
+	return fmt.Sprintf("%d/%02d", int(fy), (int(fy)+1)%100)
+}
+
+// Devanagari returns the fiscal year in Devanagari digits, e.g. "२०८२/८३".
+func (fy FiscalYear) Devanagari() string {
+	return ToDevanagari(fy.String())
+}
+
+// Start returns the first day of the fiscal year, 1 Shrawan.
+func (fy FiscalYear) Start() BSDate {
+	return BSDate{Year: int(fy), Month: Shrawan, Day: 1}
+}
+
+// End returns the last day of the fiscal year, the end of Ashadh.
+func (fy FiscalYear) End() (BSDate, error) {
+	n, err := DaysInMonth(int(fy)+1, Ashadh)
+	if err != nil {
+		return BSDate{}, err
+	}
+	return BSDate{Year: int(fy) + 1, Month: Ashadh, Day: n}, nil
+}
+
+// QuarterRange returns the first and last BS day of quarter q of the fiscal year.
+func (fy FiscalYear) QuarterRange(q Quarter) (BSDate, BSDate, error) {
+	if q < 1 || q > 4 {
+		return BSDate{}, BSDate{}, fmt.Errorf("bs: invalid quarter %d", q)
+	}
+	first := Month((int(Shrawan)-1+3*int(q-1))%12 + 1)
+	last := Month((int(first)+1)%12 + 1)
+	year := int(fy)
+	if first < Shrawan {
+		year++
+	}
+	n, err := DaysInMonth(year, last)
+	if err != nil {
+		return BSDate{}, BSDate{}, err
+	}
+	return BSDate{Year: year, Month: first, Day: 1}, BSDate{Year: year, Month: last, Day: n}, nil
+}
+
+// ADRange returns the AD dates of the first and last day of the fiscal year.
+func (fy FiscalYear) ADRange() (time.Time, time.Time, error) {
+	end, err := fy.End()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	startAD, err := fy.Start().ToAD()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	endAD, err := end.ToAD()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return startAD, endAD, nil
+}
+
+// ParseFiscalYear parses a fiscal year name as NEPSE writes it: "2082/83",
+// "2082/2083", "2082-2083" or "२०८२/८३". A name with a single year is taken as
+// the year the fiscal year starts in.
+func ParseFiscalYear(s string) (FiscalYear, error) {
+	fields := strings.FieldsFunc(ToLatin(strings.TrimSpace(s)), func(r rune) bool {
+		return r == '-' || r == '/' || r == ' '
+	})
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, fmt.Errorf("bs: invalid fiscal year %q", s)
+	}
+
+	start, err := strconv.Atoi(fields[0])
+	if err != nil || start < 1000 {
+		return 0, fmt.Errorf("bs: invalid fiscal year %q", s)
+	}
+	if len(fields) == 2 {
+		end, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return 0, fmt.Errorf("bs: invalid fiscal year %q", s)
+		}
+		// Accept both "83" and "2083" for the second year.
+		if end%100 != (start+1)%100 || (end >= 100 && end != start+1) {
+			return 0, fmt.Errorf("bs: fiscal year %q does not span consecutive years", s)
+		}
+	}
+	return FiscalYear(start), nil
+}
@@ -0,0 +1,178 @@
+package nepse
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the number of responses kept by the default in-memory cache.
+const DefaultCacheSize = 512
+
+// Cache stores raw API responses keyed by request URL.
+// Implementations must be safe for concurrent use. A user-supplied Cache may be
+// shared between clients; keys include the base URL to keep them apart.
+type Cache interface {
+	// Get returns the cached value for key, or false if absent or expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for the given time-to-live.
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete removes key from the cache.
+	Delete(key string)
+}
+
+// DefaultCacheTTLs returns the default per-endpoint cache policy, keyed by
+// [Endpoints] field name. Endpoints without an entry are never cached.
+//
+// Static reference data is kept for hours; intraday market data only for seconds.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		// Reference data
+		"SecurityList":     6 * time.Hour,
+		"CompanyList":      6 * time.Hour,
+		"CompanyProfile":   6 * time.Hour,
+		"BoardOfDirectors": 6 * time.Hour,
+		"CorporateActions": time.Hour,
+		"Reports":          time.Hour,
+		"Dividend":         time.Hour,
+
+		// Historical data
+		"CompanyPriceHistory": 10 * time.Minute,
+		"TodaysPrice":         time.Minute,
+
+		// Intraday data
+		"MarketSummary":  30 * time.Second,
+		"NepseIndex":     30 * time.Second,
+		"SupplyDemand":   30 * time.Second,
+		"TopGainers":     30 * time.Second,
+		"TopLosers":      30 * time.Second,
+		"TopTrade":       30 * time.Second,
+		"TopTransaction": 30 * time.Second,
+		"TopTurnover":    30 * time.Second,
+		"MarketOpen":     10 * time.Second,
+		"CompanyDetails": 10 * time.Second,
+		"LiveMarket":     5 * time.Second,
+		"MarketDepth":    2 * time.Second,
+	}
+}
+
+// MemoryCache is an in-memory LRU [Cache] with per-entry expiry.
+// Use [NewMemoryCache] to create one.
+type MemoryCache struct {
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates an LRU cache holding at most capacity entries.
+// If capacity is not positive, [DefaultCacheSize] is used.
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity <= 0 {
+		capacity = DefaultCacheSize
+	}
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get implements [Cache].
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expires) {
+		m.removeElement(el)
+		return nil, false
+	}
+	m.order.MoveToFront(el)
+	return entry.value, true
+}
+
+// Set implements [Cache].
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expires := time.Now().Add(ttl)
+	if el, ok := m.entries[key]; ok {
+		entry := el.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expires = expires
+		m.order.MoveToFront(el)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key: key, value: value, expires: expires})
+	for m.order.Len() > m.capacity {
+		m.removeElement(m.order.Back())
+	}
+}
+
+// Delete implements [Cache].
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.removeElement(el)
+	}
+}
+
+// Len returns the number of entries currently held, including expired ones
+// that have not been evicted yet.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+func (m *MemoryCache) removeElement(el *list.Element) {
+	m.order.Remove(el)
+	delete(m.entries, el.Value.(*memoryCacheEntry).key)
+}
+
+type cacheBypassKey struct{}
+
+// withoutCache returns a context whose requests skip cache lookups.
+// Fresh responses are still stored so later callers benefit.
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
+
+// cacheTTL returns how long responses for endpoint may be cached, or 0 if caching is disabled.
+func (c *Client) cacheTTL(endpoint string) time.Duration {
+	if c.cache == nil {
+		return 0
+	}
+	return c.cacheTTLs[c.config.Endpoints.Name(endpoint)]
+}
+
+// cacheKey scopes endpoint to the client's base URL so caches can be shared.
+func (c *Client) cacheKey(endpoint string) string {
+	return c.config.BaseURL + endpoint
+}
@@ -0,0 +1,146 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	cache := NewMemoryCache(2)
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+
+	// Touch "a" so "b" becomes least recently used
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	cache.Set("c", []byte("3"), time.Minute)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if v, ok := cache.Get("a"); !ok || string(v) != "1" {
+		t.Errorf("Get(a) = %q, %v; want 1, true", v, ok)
+	}
+	if v, ok := cache.Get("c"); !ok || string(v) != "3" {
+		t.Errorf("Get(c) = %q, %v; want 3, true", v, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	cache := NewMemoryCache(10)
+
+	cache.Set("short", []byte("x"), 10*time.Millisecond)
+	cache.Set("zero", []byte("x"), 0)
+
+	if _, ok := cache.Get("zero"); ok {
+		t.Error("entries with zero TTL should not be stored")
+	}
+	if _, ok := cache.Get("short"); !ok {
+		t.Fatal("expected short to be cached")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Get("short"); ok {
+		t.Error("expected short to expire")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expired entry should be evicted on Get, Len() = %d", cache.Len())
+	}
+}
+
+func TestEndpoints_Name(t *testing.T) {
+	e := DefaultEndpoints()
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"/api/nots/lives-market", "LiveMarket"},
+		{"/api/nots/security?nonDelisted=true", "SecurityList"},
+		{"/api/nots/security/123", "CompanyDetails"},
+		{"/api/nots/security/profile/123", "CompanyProfile"},
+		{"/api/nots/security/boardOfDirectors/123", "BoardOfDirectors"},
+		{"/api/nots/market/history/security/123?size=500", "CompanyPriceHistory"},
+		{"/api/nots/nepse-data/floorsheet?size=500&page=2", "FloorSheet"},
+		{"/api/nots/graph/index/58", "GraphNepseIndex"},
+		{"/api/unknown", ""},
+	}
+
+	for _, tt := range tests {
+		if got := e.Name(tt.endpoint); got != tt.want {
+			t.Errorf("Name(%q) = %q, want %q", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+func TestClient_ResponseCaching(t *testing.T) {
+	var liveCalls, statusCalls atomic.Int32
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/lives-market":
+			liveCalls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]LiveMarketEntry{{Symbol: "NABIL"}})
+		case "/api/nots/nepse-data/market-open":
+			statusCalls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(MarketStatus{IsOpen: "OPEN"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+		Cache:     NewMemoryCache(10),
+		CacheTTLs: map[string]time.Duration{"LiveMarket": time.Minute},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	for range 3 {
+		entries, err := client.LiveMarket(ctx)
+		if err != nil {
+			t.Fatalf("LiveMarket failed: %v", err)
+		}
+		if len(entries) != 1 || entries[0].Symbol != "NABIL" {
+			t.Fatalf("unexpected entries: %+v", entries)
+		}
+		if _, err := client.MarketStatus(ctx); err != nil {
+			t.Fatalf("MarketStatus failed: %v", err)
+		}
+	}
+
+	if liveCalls.Load() != 1 {
+		t.Errorf("expected 1 LiveMarket call (cached), got %d", liveCalls.Load())
+	}
+	// MarketOpen has no TTL in the custom policy, so every call hits the server
+	if statusCalls.Load() != 3 {
+		t.Errorf("expected 3 MarketStatus calls (uncached), got %d", statusCalls.Load())
+	}
+}
@@ -0,0 +1,256 @@
+// Package calendar implements NEPSE's trading calendar: the Sunday–Thursday
+// trading week, market holidays, and business-day arithmetic in Nepal time.
+//
+// Dates are calendar days in Nepal time. Functions accept any [time.Time],
+// convert it to Nepal time and ignore the time of day; returned dates are
+// midnight in Nepal time.
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/itsbohara/go-nepse/internal/npt"
+)
+
+// DateLayout is the layout of dates in holiday lists and NEPSE's API.
+const DateLayout = time.DateOnly
+
+//go:embed holidays.txt
+var embeddedHolidays []byte
+
+// Holiday is a day the market is closed outside the weekly schedule.
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// Calendar is a set of weekly trading days and holidays. It is safe for
+// concurrent use.
+type Calendar struct {
+	mu       sync.RWMutex
+	weekdays [7]bool
+	holidays map[string]string // DateLayout -> name
+}
+
+var defaultCalendar = New()
+
+// Default returns the shared calendar with the embedded holiday list. Holidays
+// added to it are seen by every user of Default.
+func Default() *Calendar {
+	return defaultCalendar
+}
+
+// New returns a calendar with NEPSE's Sunday–Thursday week and the embedded
+// holiday list.
+func New() *Calendar {
+	c := Empty()
+	if err := c.LoadHolidays(bytes.NewReader(embeddedHolidays)); err != nil {
+		panic("calendar: invalid embedded holiday list: " + err.Error())
+	}
+	return c
+}
+
+// Empty returns a calendar with NEPSE's Sunday–Thursday week and no holidays.
+func Empty() *Calendar {
+	c := &Calendar{holidays: make(map[string]string)}
+	for _, wd := range []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday} {
+		c.weekdays[wd] = true
+	}
+	return c
+}
+
+// Date returns midnight in Nepal time on the given day.
+func Date(year int, month time.Month, day int) time.Time {
+	return npt.Date(year, month, day)
+}
+
+// Parse parses a YYYY-MM-DD date as a day in Nepal time.
+func Parse(value string) (time.Time, error) {
+	return time.ParseInLocation(DateLayout, strings.TrimSpace(value), npt.Location)
+}
+
+// Day truncates t to midnight of its calendar day in Nepal time.
+func Day(t time.Time) time.Time {
+	return npt.Day(t)
+}
+
+// SetTradingWeekdays replaces the weekly trading days.
+func (c *Calendar) SetTradingWeekdays(days ...time.Weekday) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.weekdays = [7]bool{}
+	for _, wd := range days {
+		c.weekdays[wd] = true
+	}
+}
+
+// AddHoliday marks the day of t as a market holiday.
+func (c *Calendar) AddHoliday(t time.Time, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.holidays[Day(t).Format(DateLayout)] = name
+}
+
+// RemoveHoliday unmarks the day of t, for markets opened on a listed holiday.
+func (c *Calendar) RemoveHoliday(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.holidays, Day(t).Format(DateLayout))
+}
+
+// LoadHolidays adds holidays read from r, one "YYYY-MM-DD name" per line.
+// Blank lines and lines starting with # are ignored.
+func (c *Calendar) LoadHolidays(r io.Reader) error {
+	var parsed []Holiday
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		date, name, _ := strings.Cut(text, " ")
+		t, err := Parse(date)
+		if err != nil {
+			return fmt.Errorf("calendar: line %d: %w", line, err)
+		}
+		parsed = append(parsed, Holiday{Date: t, Name: strings.TrimSpace(name)})
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	for _, h := range parsed {
+		c.AddHoliday(h.Date, h.Name)
+	}
+	return nil
+}
+
+// Holiday returns the name of the holiday on the day of t, if any.
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	name, ok := c.holidays[Day(t).Format(DateLayout)]
+	return name, ok
+}
+
+// Holidays returns the holidays between start and end inclusive, in date order.
+func (c *Calendar) Holidays(start, end time.Time) []Holiday {
+	from, to := Day(start).Format(DateLayout), Day(end).Format(DateLayout)
+
+	c.mu.RLock()
+	var holidays []Holiday
+	for date, name := range c.holidays {
+		if date >= from && date <= to {
+			t, _ := Parse(date)
+			holidays = append(holidays, Holiday{Date: t, Name: name})
+		}
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// IsTradingDay reports whether the market trades on the day of t.
+func (c *Calendar) IsTradingDay(t time.Time) bool {
+	day := Day(t)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.weekdays[day.Weekday()] {
+		return false
+	}
+	_, holiday := c.holidays[day.Format(DateLayout)]
+	return !holiday
+}
+
+// NextTradingDay returns the first trading day after the day of t.
+func (c *Calendar) NextTradingDay(t time.Time) time.Time {
+	return c.step(Day(t), 1)
+}
+
+// PreviousTradingDay returns the last trading day before the day of t.
+func (c *Calendar) PreviousTradingDay(t time.Time) time.Time {
+	return c.step(Day(t), -1)
+}
+
+// OnOrBefore returns the day of t if it is a trading day, else the previous trading day.
+func (c *Calendar) OnOrBefore(t time.Time) time.Time {
+	if day := Day(t); c.IsTradingDay(day) {
+		return day
+	}
+	return c.PreviousTradingDay(t)
+}
+
+// OnOrAfter returns the day of t if it is a trading day, else the next trading day.
+func (c *Calendar) OnOrAfter(t time.Time) time.Time {
+	if day := Day(t); c.IsTradingDay(day) {
+		return day
+	}
+	return c.NextTradingDay(t)
+}
+
+// AddBusinessDays moves n trading days from the day of t; negative n moves
+// backwards. With n == 0 it returns [Calendar.OnOrBefore].
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	if n == 0 {
+		return c.OnOrBefore(t)
+	}
+	dir := 1
+	if n < 0 {
+		dir, n = -1, -n
+	}
+	day := Day(t)
+	for range n {
+		day = c.step(day, dir)
+	}
+	return day
+}
+
+// TradingDays returns every trading day between start and end inclusive.
+func (c *Calendar) TradingDays(start, end time.Time) []time.Time {
+	var days []time.Time
+	for day, last := Day(start), Day(end); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if c.IsTradingDay(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// BusinessDaysBetween counts the trading days after start up to and including end.
+// It is negative when end is before start.
+func (c *Calendar) BusinessDaysBetween(start, end time.Time) int {
+	if Day(end).Before(Day(start)) {
+		return -c.BusinessDaysBetween(end, start)
+	}
+	return len(c.TradingDays(Day(start).AddDate(0, 0, 1), end))
+}
+
+// step returns the next trading day from day in direction dir (1 or -1).
+// A calendar without trading weekdays returns day unchanged.
+func (c *Calendar) step(day time.Time, dir int) time.Time {
+	c.mu.RLock()
+	anyWeekday := false
+	for _, ok := range c.weekdays {
+		anyWeekday = anyWeekday || ok
+	}
+	c.mu.RUnlock()
+	if !anyWeekday {
+		return day
+	}
+
+	for {
+		day = day.AddDate(0, 0, dir)
+		if c.IsTradingDay(day) {
+			return day
+		}
+	}
+}
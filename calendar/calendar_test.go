@@ -0,0 +1,128 @@
+package calendar
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func day(t *testing.T, value string) time.Time {
+	t.Helper()
+	d, err := Parse(value)
+	if err != nil {
+		t.Fatalf("bad test date %q: %v", value, err)
+	}
+	return d
+}
+
+func format(days []time.Time) []string {
+	out := make([]string, len(days))
+	for i, d := range days {
+		out[i] = d.Format(DateLayout)
+	}
+	return out
+}
+
+func TestEmbeddedHolidays(t *testing.T) {
+	c := New()
+	if name, ok := c.Holiday(day(t, "2026-04-14")); !ok || name != "Nepali New Year" {
+		t.Errorf("Holiday(2026-04-14) = %q, %v", name, ok)
+	}
+	if c.IsTradingDay(day(t, "2026-04-14")) {
+		t.Error("New Year should not be a trading day")
+	}
+	if len(c.Holidays(day(t, "2026-10-01"), day(t, "2026-10-31"))) != 5 {
+		t.Errorf("Dashain 2026 holidays = %v", c.Holidays(day(t, "2026-10-01"), day(t, "2026-10-31")))
+	}
+}
+
+func TestIsTradingDay(t *testing.T) {
+	c := Empty()
+	// 2026-01-04 is a Sunday
+	for value, want := range map[string]bool{
+		"2026-01-04": true,
+		"2026-01-08": true,  // Thursday
+		"2026-01-09": false, // Friday
+		"2026-01-10": false, // Saturday
+	} {
+		if got := c.IsTradingDay(day(t, value)); got != want {
+			t.Errorf("IsTradingDay(%s) = %v, want %v", value, got, want)
+		}
+	}
+
+	// 2026-01-08 23:00 in UTC is already Friday in Nepal
+	if c.IsTradingDay(time.Date(2026, 1, 8, 23, 0, 0, 0, time.UTC)) {
+		t.Error("IsTradingDay should use the Nepal calendar day")
+	}
+
+	c.SetTradingWeekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+	if c.IsTradingDay(day(t, "2026-01-04")) || !c.IsTradingDay(day(t, "2026-01-09")) {
+		t.Error("SetTradingWeekdays should replace the trading week")
+	}
+}
+
+func TestBusinessDayArithmetic(t *testing.T) {
+	c := Empty()
+	c.AddHoliday(day(t, "2026-01-11"), "Prithvi Jayanti")
+
+	tests := []struct {
+		from string
+		n    int
+		want string
+	}{
+		{"2026-01-08", 1, "2026-01-12"},  // Thursday -> skips weekend and Sunday holiday
+		{"2026-01-12", -1, "2026-01-08"}, // and back
+		{"2026-01-05", 3, "2026-01-08"},
+		{"2026-01-10", 0, "2026-01-08"}, // Saturday normalizes back
+		{"2026-01-05", -5, "2025-12-29"},
+	}
+	for _, tt := range tests {
+		if got := c.AddBusinessDays(day(t, tt.from), tt.n).Format(DateLayout); got != tt.want {
+			t.Errorf("AddBusinessDays(%s, %d) = %s, want %s", tt.from, tt.n, got, tt.want)
+		}
+	}
+
+	if got := c.PreviousTradingDay(day(t, "2026-01-12")).Format(DateLayout); got != "2026-01-08" {
+		t.Errorf("PreviousTradingDay = %s", got)
+	}
+	if got := c.NextTradingDay(day(t, "2026-01-08")).Format(DateLayout); got != "2026-01-12" {
+		t.Errorf("NextTradingDay = %s", got)
+	}
+	if got := c.OnOrAfter(day(t, "2026-01-09")).Format(DateLayout); got != "2026-01-12" {
+		t.Errorf("OnOrAfter = %s", got)
+	}
+
+	days := format(c.TradingDays(day(t, "2026-01-07"), day(t, "2026-01-13")))
+	if want := []string{"2026-01-07", "2026-01-08", "2026-01-12", "2026-01-13"}; !slices.Equal(days, want) {
+		t.Errorf("TradingDays = %v, want %v", days, want)
+	}
+	if n := c.BusinessDaysBetween(day(t, "2026-01-07"), day(t, "2026-01-13")); n != 3 {
+		t.Errorf("BusinessDaysBetween = %d, want 3", n)
+	}
+	if n := c.BusinessDaysBetween(day(t, "2026-01-13"), day(t, "2026-01-07")); n != -3 {
+		t.Errorf("BusinessDaysBetween reversed = %d, want -3", n)
+	}
+}
+
+func TestLoadHolidays(t *testing.T) {
+	c := Empty()
+	err := c.LoadHolidays(strings.NewReader("# notice 2083/84\n\n2026-07-16 Special Closure\n"))
+	if err != nil {
+		t.Fatalf("LoadHolidays failed: %v", err)
+	}
+	if name, ok := c.Holiday(day(t, "2026-07-16")); !ok || name != "Special Closure" {
+		t.Errorf("Holiday = %q, %v", name, ok)
+	}
+	c.RemoveHoliday(day(t, "2026-07-16"))
+	if !c.IsTradingDay(day(t, "2026-07-16")) {
+		t.Error("RemoveHoliday should reopen the day")
+	}
+
+	if err := c.LoadHolidays(strings.NewReader("2026-07-17 ok\n16/07/2026 bad\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("LoadHolidays error = %v, want line 2", err)
+	}
+	if _, ok := c.Holiday(day(t, "2026-07-17")); ok {
+		t.Error("a failed load should not add any holidays")
+	}
+}
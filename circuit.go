@@ -0,0 +1,181 @@
+package nepse
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitBreaker configures per-endpoint circuit breaking. After Threshold
+// consecutive network or server errors an endpoint's circuit opens and requests
+// to it fail fast with [ErrCircuitOpen]. Once Cooldown has passed a single probe
+// request is let through (half-open); its outcome closes or re-opens the circuit.
+type CircuitBreaker struct {
+	Threshold int           // Consecutive failures that open the circuit; values below 1 mean 1
+	Cooldown  time.Duration // How long the circuit stays open before probing
+}
+
+// DefaultCircuitBreaker returns breaker settings tolerant of NEPSE's brief
+// outages: open after 5 consecutive failures, probe again after 30 seconds.
+// Circuit breaking is opt-in; assign it to [Options.CircuitBreaker] to enable it.
+func DefaultCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		Threshold: 5,
+		Cooldown:  30 * time.Second,
+	}
+}
+
+// CircuitState is the state of an endpoint's circuit breaker.
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // Requests flow normally
+	CircuitOpen                         // Requests fail fast with ErrCircuitOpen
+	CircuitHalfOpen                     // Cooldown elapsed; the next request probes the endpoint
+)
+
+// String returns the lowercase state name.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitStatus reports the state of an endpoint's circuit breaker.
+type CircuitStatus struct {
+	State    CircuitState
+	Failures int       // Consecutive failures counted toward opening
+	OpenedAt time.Time // When the circuit last opened; zero if it never has
+}
+
+// circuitResult classifies a request attempt for the breaker.
+type circuitResult int
+
+const (
+	circuitSuccess circuitResult = iota
+	circuitFailure
+	circuitIgnored // Attempt was abandoned (e.g. context canceled) and says nothing about the endpoint
+)
+
+// circuitBreakers tracks one circuit per endpoint label.
+type circuitBreakers struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	open     bool
+	probing  bool // A half-open probe is in flight
+	failures int
+	openedAt time.Time
+}
+
+// newCircuitBreakers returns nil if cfg is nil.
+func newCircuitBreakers(cfg *CircuitBreaker) *circuitBreakers {
+	if cfg == nil {
+		return nil
+	}
+	return &circuitBreakers{
+		threshold: max(cfg.Threshold, 1),
+		cooldown:  cfg.Cooldown,
+		circuits:  make(map[string]*circuit),
+	}
+}
+
+// allow reports whether a request to endpoint may be sent. When the cooldown
+// of an open circuit has elapsed, the first caller becomes the probe.
+func (b *circuitBreakers) allow(endpoint string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(endpoint)
+	if !c.open {
+		return true
+	}
+	if c.probing || time.Since(c.openedAt) < b.cooldown {
+		return false
+	}
+	c.probing = true
+	return true
+}
+
+// record feeds an attempt's outcome into endpoint's circuit and returns the
+// state before and after, so callers can report transitions.
+func (b *circuitBreakers) record(endpoint string, result circuitResult) (from, to CircuitState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(endpoint)
+	from = b.state(c)
+	switch result {
+	case circuitSuccess:
+		c.open, c.probing, c.failures = false, false, 0
+	case circuitFailure:
+		c.failures++
+		if c.probing || c.failures >= b.threshold {
+			c.open, c.probing = true, false
+			c.openedAt = time.Now()
+		}
+	case circuitIgnored:
+		c.probing = false
+	}
+	return from, b.state(c)
+}
+
+// status returns a snapshot of every circuit that has seen traffic.
+func (b *circuitBreakers) status() map[string]CircuitStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	out := make(map[string]CircuitStatus, len(b.circuits))
+	for endpoint, c := range b.circuits {
+		out[endpoint] = CircuitStatus{State: b.state(c), Failures: c.failures, OpenedAt: c.openedAt}
+	}
+	return out
+}
+
+func (b *circuitBreakers) circuit(endpoint string) *circuit {
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		c = &circuit{}
+		b.circuits[endpoint] = c
+	}
+	return c
+}
+
+func (b *circuitBreakers) state(c *circuit) CircuitState {
+	switch {
+	case !c.open:
+		return CircuitClosed
+	case c.probing || time.Since(c.openedAt) >= b.cooldown:
+		return CircuitHalfOpen
+	default:
+		return CircuitOpen
+	}
+}
+
+// Circuits returns the circuit breaker status of every endpoint that has been
+// requested, keyed by [Endpoints] field name ("Token" for authentication,
+// "Other" for unlisted paths). It returns nil if circuit breaking is disabled.
+func (c *Client) Circuits() map[string]CircuitStatus {
+	if c.breakers == nil {
+		return nil
+	}
+	return c.breakers.status()
+}
+
+// CircuitState returns the state of the named endpoint's circuit. Endpoints
+// that have not been requested, or clients without circuit breaking, report
+// [CircuitClosed].
+func (c *Client) CircuitState(name string) CircuitState {
+	return c.Circuits()[name].State
+}
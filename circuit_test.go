@@ -0,0 +1,162 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakers_HalfOpenProbe(t *testing.T) {
+	b := newCircuitBreakers(&CircuitBreaker{Threshold: 2, Cooldown: 20 * time.Millisecond})
+
+	b.record("LiveMarket", circuitFailure)
+	if !b.allow("LiveMarket") {
+		t.Fatal("circuit should stay closed below the threshold")
+	}
+	if _, to := b.record("LiveMarket", circuitFailure); to != CircuitOpen {
+		t.Fatalf("state after threshold = %v, want open", to)
+	}
+	if b.allow("LiveMarket") {
+		t.Error("open circuit should reject requests")
+	}
+	if !b.allow("FloorSheet") {
+		t.Error("circuits should be tracked per endpoint")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if !b.allow("LiveMarket") {
+		t.Fatal("circuit should let a probe through after the cooldown")
+	}
+	if b.allow("LiveMarket") {
+		t.Error("only one probe should be in flight")
+	}
+
+	// A failed probe re-opens the circuit for another cooldown
+	if _, to := b.record("LiveMarket", circuitFailure); to != CircuitOpen {
+		t.Errorf("state after failed probe = %v, want open", to)
+	}
+	if b.allow("LiveMarket") {
+		t.Error("re-opened circuit should reject requests")
+	}
+
+	// An abandoned probe frees the slot without closing the circuit
+	time.Sleep(30 * time.Millisecond)
+	b.allow("LiveMarket")
+	b.record("LiveMarket", circuitIgnored)
+	if !b.allow("LiveMarket") {
+		t.Error("abandoned probe should release the probe slot")
+	}
+	if _, to := b.record("LiveMarket", circuitSuccess); to != CircuitClosed {
+		t.Errorf("state after successful probe = %v, want closed", to)
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/nepse-data/market-open":
+			calls.Add(1)
+			if !healthy.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			json.NewEncoder(w).Encode(MarketStatus{IsOpen: "OPEN"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+		CircuitBreaker: &CircuitBreaker{Threshold: 2, Cooldown: 50 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	for range 2 {
+		if _, err := client.MarketStatus(ctx); !errors.Is(err, ErrInvalidServerResponse) {
+			t.Fatalf("expected server error, got %v", err)
+		}
+	}
+	if _, err := client.MarketStatus(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("open circuit should not reach the server, got %d calls", calls.Load())
+	}
+
+	status := client.Circuits()["MarketOpen"]
+	if status.State != CircuitOpen || status.Failures != 2 || status.OpenedAt.IsZero() {
+		t.Errorf("unexpected MarketOpen status: %+v", status)
+	}
+	if got := client.CircuitState("Token"); got != CircuitClosed {
+		t.Errorf("Token circuit = %v, want closed", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if got := client.CircuitState("MarketOpen"); got != CircuitHalfOpen {
+		t.Errorf("state after cooldown = %v, want half-open", got)
+	}
+
+	healthy.Store(true)
+	if _, err := client.MarketStatus(ctx); err != nil {
+		t.Fatalf("probe request failed: %v", err)
+	}
+	if got := client.CircuitState("MarketOpen"); got != CircuitClosed {
+		t.Errorf("state after successful probe = %v, want closed", got)
+	}
+}
+
+func TestClient_CircuitOpenDuringRetriesKeepsCause(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/nepse-data/market-open":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	client := newTestClient(t, handler, func(o *Options) {
+		o.MaxRetries = 3
+		o.RetryDelay = time.Millisecond
+		o.CircuitBreaker = &CircuitBreaker{Threshold: 2, Cooldown: time.Minute}
+	})
+
+	// The second attempt opens the circuit, so the third is refused
+	_, err := client.MarketStatus(context.Background())
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if cause := errors.Unwrap(err); !errors.Is(cause, ErrInvalidServerResponse) {
+		t.Errorf("circuit error should wrap the 503 that opened it, got %v", err)
+	}
+
+	// Once open, a new request fails fast without a cause
+	_, err = client.MarketStatus(context.Background())
+	if !errors.Is(err, ErrCircuitOpen) || errors.Unwrap(err) != nil {
+		t.Errorf("expected a bare circuit error, got %v", err)
+	}
+}
@@ -1,9 +1,11 @@
 package nepse
 
 import (
+	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/itsbohara/go-nepse/calendar"
 	"github.com/itsbohara/go-nepse/internal/auth"
 )
 
@@ -13,6 +15,19 @@ type Client struct {
 	config      *Config
 	authManager *auth.Manager
 	options     *Options
+
+	cache     Cache
+	cacheTTLs map[string]time.Duration
+	resolver  *SymbolResolver
+	limiter   *rateLimiter
+	roundTrip RoundTripFunc
+	logger    *slog.Logger
+	metrics   Metrics
+
+	retryPolicy RetryPolicy
+	breakers    *circuitBreakers
+
+	clock Clock
 }
 
 // Options configures the NEPSE client.
@@ -21,9 +36,57 @@ type Options struct {
 	TLSVerification bool          // Set false only for development; NEPSE uses self-signed certs
 	HTTPTimeout     time.Duration // Per-request timeout
 	MaxRetries      int           // Retry count for transient failures (5xx, rate limits)
-	RetryDelay      time.Duration // Base delay; actual delay uses exponential backoff
+	RetryDelay      time.Duration // Base delay for the default exponential backoff
 	Config          *Config       // API endpoint paths and headers
 	HTTPClient      *http.Client  // Bring your own client; nil uses sensible defaults
+
+	// Cache stores GET responses; nil disables caching.
+	Cache Cache
+	// CacheTTLs maps Endpoints field names (e.g. "LiveMarket") to cache lifetimes.
+	// Endpoints missing from the map are not cached. Nil uses DefaultCacheTTLs().
+	CacheTTLs map[string]time.Duration
+
+	// SymbolRefreshInterval controls how often the symbol index behind the
+	// *BySymbol methods is rebuilt in the background; zero disables the schedule.
+	SymbolRefreshInterval time.Duration
+
+	// RateLimit paces outgoing requests; nil (the default) disables client-side
+	// limiting. See [DefaultRateLimit].
+	RateLimit *RateLimit
+
+	// Middleware wraps every request attempt; the first entry is the outermost.
+	Middleware []Middleware
+
+	// Logger receives structured events for request attempts, retries, token
+	// refreshes and decode failures; nil disables logging.
+	Logger *slog.Logger
+
+	// Metrics receives request, retry, token refresh and cache instrumentation;
+	// nil disables metrics. See [PrometheusMetrics] for a ready-made adapter.
+	Metrics Metrics
+
+	// RetryPolicy decides which failed attempts are retried and how long to
+	// wait; nil uses [ExponentialBackoff] starting at RetryDelay.
+	RetryPolicy RetryPolicy
+
+	// CircuitBreaker fails requests fast while an endpoint keeps failing;
+	// nil (the default) disables circuit breaking. See [DefaultCircuitBreaker].
+	CircuitBreaker *CircuitBreaker
+
+	// Calendar, when set, validates and normalizes the dates passed to
+	// [Client.PriceHistory] and [Client.TodaysPrices] to trading days, so
+	// weekends and holidays are never requested. See [calendar.Default].
+	Calendar *calendar.Calendar
+
+	// Clock supplies the current time for token expiry, graph payload days,
+	// session checks and live market polling; nil uses [SystemClock]. See
+	// nepsetest.Clock for tests.
+	Clock Clock
+
+	// DecimalPrices, when true, also decodes the amounts of floor sheet, today's
+	// price, live market and security detail responses exactly, into the
+	// Decimal field of each entry. It costs a second decode of those responses.
+	DecimalPrices bool
 }
 
 // DefaultOptions returns sensible defaults for the NEPSE client.
@@ -35,6 +98,9 @@ func DefaultOptions() *Options {
 		MaxRetries:      3,
 		RetryDelay:      time.Second,
 		Config:          DefaultConfig(),
+		Cache:           NewMemoryCache(DefaultCacheSize),
+
+		SymbolRefreshInterval: DefaultSymbolRefreshInterval,
 	}
 }
 
@@ -55,8 +121,16 @@ func (c *Client) Config() *Config {
 	return c.config
 }
 
+// Symbols returns the client's symbol resolver.
+func (c *Client) Symbols() *SymbolResolver {
+	return c.resolver
+}
+
 // Close releases resources held by the client.
 func (c *Client) Close() error {
+	if c.resolver != nil {
+		c.resolver.close()
+	}
 	if c.authManager != nil {
 		return c.authManager.Close()
 	}
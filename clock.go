@@ -0,0 +1,25 @@
+package nepse
+
+import "time"
+
+// Clock tells the current time. Set [Options.Clock] to control the time the
+// client sees for token expiry, graph payload days, session checks and
+// live market polling.
+// Request latency, retries and caching always use the system clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the [Clock] backed by [time.Now].
+type SystemClock struct{}
+
+// Now returns the current local time.
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// ClockSkew returns how far NEPSE's server clock is ahead of [Options.Clock]
+// (negative if behind), measured from the server time in the last token
+// response. Token expiry and graph payload days are corrected by it. It is
+// zero until the first token has been fetched.
+func (c *Client) ClockSkew() time.Duration {
+	return c.authManager.Skew()
+}
@@ -1,5 +1,10 @@
 package nepse
 
+import (
+	"reflect"
+	"strings"
+)
+
 // DefaultBaseURL is the production NEPSE API URL.
 const DefaultBaseURL = "https://nepalstock.com.np"
 
@@ -61,6 +66,35 @@ type Endpoints struct {
 	CompanyDailyGraph string
 }
 
+// Name returns the name of the Endpoints field that serves endpoint, or "" if none does.
+// Parameterized endpoints such as "/api/nots/security/profile/123" resolve to the
+// longest matching path prefix ("CompanyProfile"). Query strings only matter for
+// exact matches, which distinguishes SecurityList from CompanyDetails.
+func (e Endpoints) Name(endpoint string) string {
+	v := reflect.ValueOf(e)
+	t := v.Type()
+
+	for i := range t.NumField() {
+		if v.Field(i).String() == endpoint {
+			return t.Field(i).Name
+		}
+	}
+
+	path, _, _ := strings.Cut(endpoint, "?")
+	best, bestLen := "", 0
+	for i := range t.NumField() {
+		fieldPath, query, _ := strings.Cut(v.Field(i).String(), "?")
+		if fieldPath == "" || (path != fieldPath && !strings.HasPrefix(path, fieldPath+"/")) {
+			continue
+		}
+		// Prefer the longest prefix; on ties prefer fields without a fixed query.
+		if len(fieldPath) > bestLen || (len(fieldPath) == bestLen && query == "") {
+			best, bestLen = t.Field(i).Name, len(fieldPath)
+		}
+	}
+	return best
+}
+
 // Config holds configuration for the NEPSE API client.
 type Config struct {
 	BaseURL   string
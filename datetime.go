@@ -0,0 +1,209 @@
+package nepse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsbohara/go-nepse/internal/npt"
+)
+
+// NPT is Nepal Standard Time (UTC+05:45), the zone NEPSE reports times in and
+// computes graph payloads with. It is Asia/Kathmandu from the system's time
+// zone database, or from a copy embedded in the module when the system has
+// none, so it is never nil.
+var NPT = npt.Location
+
+// DateTimeLayout is the layout NEPSE uses for timestamps, in Nepal time
+// without a zone. Fractional seconds are optional.
+const DateTimeLayout = "2006-01-02T15:04:05.999999999"
+
+// timeLayouts are the formats NEPSE timestamps and dates are reported in.
+// Values without a zone are taken as Nepal time.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	DateTimeLayout,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	time.DateOnly,
+}
+
+// parseNepseTime parses value in any of timeLayouts and returns it in Nepal time.
+func parseNepseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, NPT); err == nil {
+			return t.In(NPT), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("nepse: unrecognized time %q", value)
+}
+
+// unmarshalNepseTime decodes a JSON string or null into a time. Null and the
+// empty string decode to the zero time.
+func unmarshalNepseTime(data []byte) (time.Time, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return time.Time{}, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return time.Time{}, fmt.Errorf("nepse: time must be a string, got %s", data)
+	}
+	if strings.TrimSpace(s) == "" {
+		return time.Time{}, nil
+	}
+	return parseNepseTime(s)
+}
+
+// marshalNepseTime formats t with layout in Nepal time, or returns null for
+// the zero time.
+func marshalNepseTime(t time.Time, layout string) []byte {
+	if t.IsZero() {
+		return []byte("null")
+	}
+	return []byte(`"` + t.In(NPT).Format(layout) + `"`)
+}
+
+// Date is a calendar day reported by NEPSE, such as a business date, held as
+// midnight Nepal time. It decodes from "2006-01-02" or a full timestamp, whose
+// day in Nepal time is kept. JSON null and "" decode to the zero Date.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the day of t in Nepal time.
+func NewDate(t time.Time) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+	t = t.In(NPT)
+	return Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, NPT)}
+}
+
+// ParseDate parses a date or timestamp in any format NEPSE reports.
+func ParseDate(value string) (Date, error) {
+	t, err := parseNepseTime(value)
+	if err != nil {
+		return Date{}, err
+	}
+	return NewDate(t), nil
+}
+
+// String formats d as YYYY-MM-DD, or returns "" for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.In(NPT).Format(time.DateOnly)
+}
+
+// MarshalJSON writes d as "YYYY-MM-DD", or null for the zero Date.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return marshalNepseTime(d.Time, time.DateOnly), nil
+}
+
+// UnmarshalJSON decodes a NEPSE date or timestamp string.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	t, err := decodeDate(data)
+	if err != nil {
+		return err
+	}
+	*d = Date{Time: t}
+	return nil
+}
+
+// MarshalText formats d as YYYY-MM-DD.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a NEPSE date or timestamp; empty text is the zero Date.
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(bytes.TrimSpace(text)) == 0 {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func decodeDate(data []byte) (time.Time, error) {
+	t, err := unmarshalNepseTime(data)
+	return NewDate(t).Time, err
+}
+
+// DateTime is a timestamp reported by NEPSE, held in Nepal time. It decodes
+// from DateTimeLayout with or without fractional seconds, the same with a
+// space instead of 'T', RFC 3339 with a zone, or a bare date. JSON null and ""
+// decode to the zero DateTime.
+type DateTime struct {
+	time.Time
+}
+
+// NewDateTime returns t in Nepal time.
+func NewDateTime(t time.Time) DateTime {
+	if t.IsZero() {
+		return DateTime{}
+	}
+	return DateTime{Time: t.In(NPT)}
+}
+
+// ParseDateTime parses a timestamp in any format NEPSE reports.
+func ParseDateTime(value string) (DateTime, error) {
+	t, err := parseNepseTime(value)
+	if err != nil {
+		return DateTime{}, err
+	}
+	return DateTime{Time: t}, nil
+}
+
+// String formats dt with DateTimeLayout, or returns "" for the zero DateTime.
+func (dt DateTime) String() string {
+	if dt.IsZero() {
+		return ""
+	}
+	return dt.In(NPT).Format(DateTimeLayout)
+}
+
+// MarshalJSON writes dt with DateTimeLayout in Nepal time, or null for the
+// zero DateTime.
+func (dt DateTime) MarshalJSON() ([]byte, error) {
+	return marshalNepseTime(dt.Time, DateTimeLayout), nil
+}
+
+// UnmarshalJSON decodes a NEPSE timestamp string.
+func (dt *DateTime) UnmarshalJSON(data []byte) error {
+	t, err := unmarshalNepseTime(data)
+	if err != nil {
+		return err
+	}
+	*dt = DateTime{Time: t}
+	return nil
+}
+
+// MarshalText formats dt with DateTimeLayout.
+func (dt DateTime) MarshalText() ([]byte, error) {
+	return []byte(dt.String()), nil
+}
+
+// UnmarshalText parses a NEPSE timestamp; empty text is the zero DateTime.
+func (dt *DateTime) UnmarshalText(text []byte) error {
+	if len(bytes.TrimSpace(text)) == 0 {
+		*dt = DateTime{}
+		return nil
+	}
+	parsed, err := ParseDateTime(string(text))
+	if err != nil {
+		return err
+	}
+	*dt = parsed
+	return nil
+}
@@ -0,0 +1,86 @@
+package nepse
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateTime_UnmarshalFormats(t *testing.T) {
+	want := nptTime(t, "2026-01-05 15:00")
+	for _, value := range []string{
+		`"2026-01-05T15:00:00"`,
+		`"2026-01-05T15:00:00.0"`,
+		`"2026-01-05 15:00:00"`,
+		`"2026-01-05T15:00"`,
+		`"2026-01-05T15:00:00+05:45"`,
+		`"2026-01-05T09:15:00Z"`,
+		`"2026-01-05T15:00:00.000+0545"`,
+	} {
+		var dt DateTime
+		if err := json.Unmarshal([]byte(value), &dt); err != nil {
+			t.Errorf("Unmarshal(%s) failed: %v", value, err)
+			continue
+		}
+		if !dt.Equal(want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", value, dt.Time, want)
+		}
+		if dt.Location() != NPT {
+			t.Errorf("Unmarshal(%s) location = %v, want Nepal time", value, dt.Location())
+		}
+	}
+
+	var dt DateTime
+	if err := json.Unmarshal([]byte(`"yesterday"`), &dt); err == nil {
+		t.Error("Unmarshal should reject unknown formats")
+	}
+	for _, value := range []string{`null`, `""`} {
+		if err := json.Unmarshal([]byte(value), &dt); err != nil || !dt.IsZero() {
+			t.Errorf("Unmarshal(%s) = %v, %v; want zero", value, dt.Time, err)
+		}
+	}
+}
+
+func TestDate_Unmarshal(t *testing.T) {
+	for _, value := range []string{`"2026-01-05"`, `"2026-01-05T14:59:58.123"`, `"2026-01-04T20:00:00Z"`} {
+		var d Date
+		if err := json.Unmarshal([]byte(value), &d); err != nil {
+			t.Fatalf("Unmarshal(%s) failed: %v", value, err)
+		}
+		if !d.Equal(nptTime(t, "2026-01-05 00:00")) || d.String() != "2026-01-05" {
+			t.Errorf("Unmarshal(%s) = %v, want 2026-01-05 midnight NPT", value, d.Time)
+		}
+	}
+}
+
+func TestDateTime_MarshalCanonical(t *testing.T) {
+	input := `{"businessDate":"2026-01-05T00:00:00","lastUpdatedDateTime":"2026-01-05 14:59:58.123","tradeTime":null}`
+	var v struct {
+		BusinessDate        Date     `json:"businessDate"`
+		LastUpdatedDateTime DateTime `json:"lastUpdatedDateTime"`
+		TradeTime           DateTime `json:"tradeTime"`
+	}
+	if err := json.Unmarshal([]byte(input), &v); err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"businessDate":"2026-01-05","lastUpdatedDateTime":"2026-01-05T14:59:58.123","tradeTime":null}`; string(out) != want {
+		t.Errorf("marshal = %s, want %s", out, want)
+	}
+
+	// Values for the same instant are equal however NEPSE formatted them
+	var zoned DateTime
+	if err := json.Unmarshal([]byte(`"2026-01-05T09:14:58.123Z"`), &zoned); err != nil {
+		t.Fatal(err)
+	}
+	if zoned != v.LastUpdatedDateTime {
+		t.Errorf("%v != %v", zoned, v.LastUpdatedDateTime)
+	}
+	days := map[Date]bool{NewDate(time.Date(2026, 1, 4, 20, 0, 0, 0, time.UTC)): true}
+	if !days[v.BusinessDate] {
+		t.Errorf("decoded %v should match the constructed date as a map key", v.BusinessDate)
+	}
+}
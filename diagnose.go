@@ -0,0 +1,370 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultSlowThreshold is the latency above which [Client.Diagnose] reports an
+// otherwise working endpoint as slow.
+const DefaultSlowThreshold = 3 * time.Second
+
+// diagnoseConcurrency bounds how many probes [Client.Diagnose] runs at once.
+const diagnoseConcurrency = 4
+
+// EndpointStatus classifies the outcome of probing an endpoint.
+type EndpointStatus string
+
+const (
+	EndpointWorking       EndpointStatus = "working"        // Responded with data in the expected shape
+	EndpointBlocked       EndpointStatus = "blocked"        // 403 even with a fresh token
+	EndpointEmpty         EndpointStatus = "empty"          // Responded, but with no data
+	EndpointSlow          EndpointStatus = "slow"           // Working, but slower than DefaultSlowThreshold
+	EndpointSchemaChanged EndpointStatus = "schema-changed" // Responded with data the client cannot decode
+	EndpointFailing       EndpointStatus = "failing"        // Any other error
+)
+
+// EndpointHealth is the result of probing a single endpoint.
+type EndpointHealth struct {
+	Name       string         // Endpoints field name
+	Method     string         // HTTP method used for the probe
+	Endpoint   string         // Path and query probed
+	Status     EndpointStatus // Classification of the probe
+	StatusCode int            // HTTP status of a failed probe; 0 on success or transport errors
+	Latency    time.Duration
+	Err        error // Why the probe was not working, if it wasn't
+}
+
+// DiagnosticReport is the endpoint health matrix produced by [Client.Diagnose].
+type DiagnosticReport struct {
+	Time      time.Time        // When the diagnosis started
+	ClockSkew time.Duration    // NEPSE's clock minus the local clock; see [Client.ClockSkew]
+	Endpoints []EndpointHealth // In Endpoints field order; graph endpoints appear once per method
+}
+
+// Filter returns the endpoints with the given status.
+func (r *DiagnosticReport) Filter(status EndpointStatus) []EndpointHealth {
+	var out []EndpointHealth
+	for _, e := range r.Endpoints {
+		if e.Status == status {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// String renders the report as an aligned table, preceded by the clock skew
+// when it is a second or more.
+func (r *DiagnosticReport) String() string {
+	var b strings.Builder
+	if skew := r.ClockSkew.Round(time.Second); skew != 0 {
+		fmt.Fprintf(&b, "clock skew: %s\n", skew)
+	}
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tMETHOD\tSTATUS\tLATENCY\tDETAIL")
+	for _, e := range r.Endpoints {
+		detail := ""
+		if e.Err != nil {
+			detail = e.Err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Name, e.Method, e.Status, e.Latency.Round(time.Millisecond), detail)
+	}
+	_ = w.Flush()
+	return b.String()
+}
+
+// probe describes how to exercise one endpoint.
+type probe struct {
+	name     string
+	method   string
+	endpoint string
+	payload  payloadFunc // POST body; nil for GET
+	expect   []any       // Acceptable response shapes; nil accepts any JSON
+	err      error       // Set when the probe cannot be built
+}
+
+// Diagnose probes every path in [Endpoints], bypassing the cache, and reports
+// which ones work, are blocked, return no data, are slow, or return data in a
+// shape the client no longer understands. Graph endpoints are probed with both
+// GET and the POST payload the client normally sends. Per-security endpoints
+// use the first active security from the security list.
+//
+// Probes are sent once, without retries, and bypass the circuit breakers.
+// Diagnose only returns an error if ctx is done; endpoint failures are part of the report.
+func (c *Client) Diagnose(ctx context.Context) (*DiagnosticReport, error) {
+	report := &DiagnosticReport{Time: c.clock.Now()}
+	probes := c.diagnosticProbes(ctx)
+	report.Endpoints = make([]EndpointHealth, len(probes))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(diagnoseConcurrency)
+	for i, p := range probes {
+		g.Go(func() error {
+			report.Endpoints[i] = c.runProbe(gctx, p)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	report.ClockSkew = c.ClockSkew()
+	return report, nil
+}
+
+// runProbe sends a single probe and classifies the result.
+func (c *Client) runProbe(ctx context.Context, p probe) EndpointHealth {
+	h := EndpointHealth{Name: p.name, Method: p.method, Endpoint: p.endpoint}
+	if p.err != nil {
+		h.Status, h.Err = EndpointFailing, p.err
+		return h
+	}
+
+	ctx = asProbe(ctx)
+	var data []byte
+	var err error
+	start := time.Now()
+	if p.method == http.MethodPost {
+		data, err = c.apiPostRequestRaw(ctx, p.endpoint, p.payload)
+	} else {
+		data, err = c.fetchRaw(withoutCache(ctx), p.endpoint)
+	}
+	h.Latency = time.Since(start)
+	h.Status, h.Err = classifyProbe(data, err, p.expect, h.Latency)
+
+	var nepseErr *NepseError
+	if errors.As(err, &nepseErr) {
+		h.StatusCode = nepseErr.statusCode
+	}
+	return h
+}
+
+type probeKey struct{}
+
+// asProbe marks ctx as belonging to a diagnostic probe. Probes are sent once,
+// without retries, and neither consult nor feed the circuit breakers, so a
+// diagnosis sees each endpoint as it is and leaves client state alone.
+func asProbe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, probeKey{}, true)
+}
+
+func isProbe(ctx context.Context) bool {
+	probe, _ := ctx.Value(probeKey{}).(bool)
+	return probe
+}
+
+// classifyProbe maps a probe's response to an EndpointStatus.
+func classifyProbe(data []byte, err error, expect []any, latency time.Duration) (EndpointStatus, error) {
+	switch {
+	case errors.Is(err, ErrEndpointBlocked):
+		return EndpointBlocked, err
+	case err != nil:
+		return EndpointFailing, err
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return EndpointSchemaChanged, fmt.Errorf("response is not JSON: %w", err)
+	}
+	if isEmptyJSON(v) {
+		return EndpointEmpty, nil
+	}
+	if err := matchesShape(data, expect); err != nil {
+		return EndpointSchemaChanged, err
+	}
+	if latency > DefaultSlowThreshold {
+		return EndpointSlow, nil
+	}
+	return EndpointWorking, nil
+}
+
+// isEmptyJSON reports whether a decoded JSON value carries no data: null, an
+// empty array or object, or a (possibly wrapped) page whose content is empty.
+func isEmptyJSON(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		if len(v) == 0 {
+			return true
+		}
+		if content, ok := v["content"]; ok {
+			return isEmptyJSON(content)
+		}
+		if len(v) == 1 {
+			for _, inner := range v {
+				if m, ok := inner.(map[string]any); ok {
+					return isEmptyJSON(m)
+				}
+			}
+		}
+	}
+	return false
+}
+
+// matchesShape reports an error unless data decodes into one of the expected
+// types and shares at least one field with it. The field check catches
+// responses that decode cleanly only because every field was renamed.
+func matchesShape(data []byte, expect []any) error {
+	if len(expect) == 0 {
+		return nil
+	}
+	var lastErr error
+	for _, want := range expect {
+		target := reflect.New(reflect.TypeOf(want))
+		if err := json.Unmarshal(data, target.Interface()); err != nil {
+			lastErr = err
+			continue
+		}
+		if !sharesFields(data, target.Elem().Type()) {
+			lastErr = fmt.Errorf("no %s fields in response", target.Elem().Type())
+			continue
+		}
+		return nil
+	}
+	return lastErr
+}
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// sharesFields reports whether the first JSON object in data has a key matching
+// a field of t (or of t's element type). Types that decode themselves, or whose
+// data is not an object, always match.
+func sharesFields(data []byte, t reflect.Type) bool {
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return true
+	}
+
+	var v any
+	_ = json.Unmarshal(data, &v)
+	if arr, ok := v.([]any); ok && len(arr) > 0 {
+		v = arr[0]
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return true
+	}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		for key := range obj {
+			if strings.EqualFold(key, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// diagnosticProbes builds a probe for every Endpoints field, in field order.
+func (c *Client) diagnosticProbes(ctx context.Context) []probe {
+	ep := c.config.Endpoints
+
+	var sampleID int32
+	var sampleErr error
+	if securities, err := c.Securities(ctx); err != nil {
+		sampleErr = fmt.Errorf("no security to probe with: %w", err)
+	} else if len(securities) == 0 {
+		sampleErr = errors.New("no security to probe with: security list is empty")
+	} else {
+		sampleID = securities[0].ID
+		for _, s := range securities {
+			if s.ActiveStatus == "A" {
+				sampleID = s.ID
+				break
+			}
+		}
+	}
+	perSecurity := func(base string) string { return fmt.Sprintf("%s/%d", base, sampleID) }
+	today := c.authManager.ServerNow().In(NPT).Format(time.DateOnly)
+
+	var scripPayload, indexPayload payloadFunc
+	var payloadErr error
+	if id, err := c.computeScripGraphPayloadID(ctx); err != nil {
+		payloadErr = err
+	} else {
+		scripPayload = staticPayload(graphPostPayload{ID: id})
+		indexPayload, payloadErr = c.indexGraphPayload(ctx)
+	}
+
+	// Endpoints whose probe differs from a plain GET of the path.
+	gets := map[string]probe{
+		"MarketSummary":       {expect: []any{[]MarketSummaryItem{}}},
+		"MarketOpen":          {expect: []any{MarketStatus{}}},
+		"LiveMarket":          {expect: []any{[]LiveMarketEntry{}}},
+		"SupplyDemand":        {expect: []any{SupplyDemandData{}}},
+		"TodaysPrice":         {expect: []any{[]TodayPrice{}}},
+		"FloorSheet":          {endpoint: ep.FloorSheet + "?size=20&sort=contractId,desc", expect: []any{[]FloorSheetEntry{}, FloorSheetResponse{}}},
+		"NepseIndex":          {expect: []any{[]NepseIndexRaw{}}},
+		"TopGainers":          {expect: []any{[]TopGainerLoserEntry{}}},
+		"TopLosers":           {expect: []any{[]TopGainerLoserEntry{}}},
+		"TopTrade":            {expect: []any{[]TopTradeEntry{}}},
+		"TopTransaction":      {expect: []any{[]TopTransactionEntry{}}},
+		"TopTurnover":         {expect: []any{[]TopTurnoverEntry{}}},
+		"SecurityList":        {expect: []any{[]Security{}}},
+		"CompanyList":         {expect: []any{[]Company{}}},
+		"CompanyDetails":      {endpoint: perSecurity(ep.CompanyDetails), expect: []any{CompanyDetailsRaw{}}, err: sampleErr},
+		"CompanyPriceHistory": {endpoint: perSecurity(ep.CompanyPriceHistory) + "?size=20", expect: []any{PaginatedResponse[PriceHistory]{}}, err: sampleErr},
+		"CompanyFloorsheet":   {endpoint: perSecurity(ep.CompanyFloorsheet) + "?size=20&businessDate=" + today, expect: []any{FloorSheetResponse{}}, err: sampleErr},
+		"MarketDepth":         {endpoint: perSecurity(ep.MarketDepth), expect: []any{MarketDepthRaw{}}, err: sampleErr},
+		"CompanyProfile":      {endpoint: perSecurity(ep.CompanyProfile), expect: []any{CompanyProfile{}}, err: sampleErr},
+		"BoardOfDirectors":    {endpoint: perSecurity(ep.BoardOfDirectors), expect: []any{[]BoardMember{}}, err: sampleErr},
+		"CorporateActions":    {endpoint: perSecurity(ep.CorporateActions), expect: []any{[]CorporateAction{}}, err: sampleErr},
+		"Reports":             {endpoint: perSecurity(ep.Reports), expect: []any{[]Report{}}, err: sampleErr},
+		"Dividend":            {endpoint: perSecurity(ep.Dividend), expect: []any{[]Dividend{}}, err: sampleErr},
+		"CompanyDailyGraph":   {endpoint: perSecurity(ep.CompanyDailyGraph), expect: []any{[]GraphDataPoint{}}, err: sampleErr},
+	}
+	// Endpoints the client calls with POST, probed with both methods.
+	posts := map[string]probe{
+		"CompanyDetails":    {payload: scripPayload, expect: []any{SecurityDetailRaw{}}, err: errors.Join(sampleErr, payloadErr)},
+		"CompanyDailyGraph": {payload: scripPayload, expect: []any{[]GraphDataPoint{}}, err: errors.Join(sampleErr, payloadErr)},
+	}
+
+	var probes []probe
+	v := reflect.ValueOf(ep)
+	for i := range v.NumField() {
+		name, path := v.Type().Field(i).Name, v.Field(i).String()
+		if path == "" {
+			continue
+		}
+
+		get := gets[name]
+		if strings.HasPrefix(name, "Graph") {
+			get.expect = []any{[]GraphDataPoint{}}
+			posts[name] = probe{payload: indexPayload, expect: get.expect, err: payloadErr}
+		}
+		get.name, get.method = name, http.MethodGet
+		if get.endpoint == "" {
+			get.endpoint = path
+		}
+		probes = append(probes, get)
+
+		if post, ok := posts[name]; ok {
+			post.name, post.method, post.endpoint = name, http.MethodPost, get.endpoint
+			probes = append(probes, post)
+		}
+	}
+	return probes
+}
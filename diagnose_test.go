@@ -0,0 +1,184 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsbohara/go-nepse/nepsetest"
+)
+
+func diagnoseHandler(tokenCalls *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/authenticate/prove":
+			tokenCalls.Add(1)
+			json.NewEncoder(w).Encode(tokenResponse())
+		case r.URL.Path == "/api/nots/nepse-data/market-open":
+			json.NewEncoder(w).Encode(MarketStatus{IsOpen: "OPEN", ID: 3})
+		case r.URL.Path == "/api/nots/security" && r.URL.RawQuery == "nonDelisted=true":
+			w.Write([]byte(`[{"id":9,"symbol":"OLD","activeStatus":"S"},{"id":131,"symbol":"NABIL","activeStatus":"A"}]`))
+		case r.URL.Path == "/api/nots/lives-market":
+			w.Write([]byte(`[]`))
+		case r.URL.Path == "/api/nots/nepse-data/today-price":
+			w.Write([]byte(`{"prices":[]}`))
+		case r.URL.Path == "/api/nots/top-ten/top-gainer":
+			w.Write([]byte(`[{"symbol":"NABIL","ltp":512.5}]`))
+		case strings.HasPrefix(r.URL.Path, "/api/nots/security/floorsheet/"):
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path == "/api/nots/graph/index/58" && r.Method == http.MethodPost:
+			w.Write([]byte(`[[1767600000,2650.5]]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestClient_Diagnose(t *testing.T) {
+	var tokenCalls atomic.Int32
+	client := newTestClient(t, diagnoseHandler(&tokenCalls))
+
+	report, err := client.Diagnose(context.Background())
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	type key struct{ name, method string }
+	got := make(map[key]EndpointHealth)
+	for _, h := range report.Endpoints {
+		got[key{h.Name, h.Method}] = h
+	}
+
+	want := map[key]EndpointStatus{
+		{"MarketOpen", http.MethodGet}:        EndpointWorking,
+		{"SecurityList", http.MethodGet}:      EndpointWorking,
+		{"LiveMarket", http.MethodGet}:        EndpointEmpty,
+		{"TodaysPrice", http.MethodGet}:       EndpointSchemaChanged,
+		{"TopGainers", http.MethodGet}:        EndpointWorking,
+		{"CompanyFloorsheet", http.MethodGet}: EndpointBlocked,
+		{"MarketSummary", http.MethodGet}:     EndpointFailing,
+		{"GraphNepseIndex", http.MethodPost}:  EndpointWorking,
+		{"GraphNepseIndex", http.MethodGet}:   EndpointFailing,
+		{"CompanyDetails", http.MethodPost}:   EndpointFailing,
+	}
+	for k, status := range want {
+		h, ok := got[k]
+		if !ok {
+			t.Errorf("%s %s missing from report", k.method, k.name)
+			continue
+		}
+		if h.Status != status {
+			t.Errorf("%s %s status = %s, want %s (err: %v)", k.method, k.name, h.Status, status, h.Err)
+		}
+	}
+
+	// 17 index graphs are probed twice, as are CompanyDetails and CompanyDailyGraph
+	if n := len(report.Endpoints); n != 41+17+2 {
+		t.Errorf("report has %d entries, want %d", n, 41+17+2)
+	}
+
+	blocked := got[key{"CompanyFloorsheet", http.MethodGet}]
+	if blocked.StatusCode != http.StatusForbidden || !strings.HasPrefix(blocked.Endpoint, "/api/nots/security/floorsheet/131?") {
+		t.Errorf("blocked probe should use the active sample security, got %+v", blocked)
+	}
+	if len(report.Filter(EndpointBlocked)) != 1 {
+		t.Errorf("Filter(blocked) = %v", report.Filter(EndpointBlocked))
+	}
+	if !strings.Contains(report.String(), "CompanyFloorsheet") {
+		t.Errorf("String() should list endpoints:\n%s", report)
+	}
+}
+
+func TestClient_EndpointBlocked(t *testing.T) {
+	var tokenCalls atomic.Int32
+	clock := nepsetest.NewClock(time.Date(2026, 1, 5, 11, 0, 0, 0, NPT))
+	client := newTestClient(t, diagnoseHandler(&tokenCalls), func(o *Options) { o.Clock = clock })
+	ctx := context.Background()
+
+	_, err := client.FloorSheetOf(ctx, 131, "2026-01-05")
+	if !errors.Is(err, ErrEndpointBlocked) {
+		t.Fatalf("expected ErrEndpointBlocked, got %v", err)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Error("ErrEndpointBlocked should still match ErrUnauthorized")
+	}
+	if !strings.Contains(err.Error(), "CompanyFloorsheet blocked by NEPSE") {
+		t.Errorf("unexpected message: %v", err)
+	}
+	// The token was fetched for this request, so the 403 is reported at once
+	if tokenCalls.Load() != 1 {
+		t.Errorf("expected 1 token fetch, got %d", tokenCalls.Load())
+	}
+
+	// An older token is refreshed once before the endpoint is reported as blocked
+	clock.Advance(blockedTokenAge)
+	if _, err := client.FloorSheetOf(ctx, 131, "2026-01-05"); !errors.Is(err, ErrEndpointBlocked) {
+		t.Fatalf("expected ErrEndpointBlocked with an older token, got %v", err)
+	}
+	if tokenCalls.Load() != 2 {
+		t.Errorf("expected 2 token fetches, got %d", tokenCalls.Load())
+	}
+}
+
+func TestClient_DiagnoseSendsProbesOnce(t *testing.T) {
+	var tokenCalls, summaryCalls atomic.Int32
+	base := diagnoseHandler(&tokenCalls)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/nots/market-summary" {
+			summaryCalls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		base.ServeHTTP(w, r)
+	})
+	client := newTestClient(t, handler, func(o *Options) {
+		o.MaxRetries = 3
+		o.RetryDelay = time.Millisecond
+		o.CircuitBreaker = &CircuitBreaker{Threshold: 1, Cooldown: time.Minute}
+	})
+
+	if _, err := client.Diagnose(context.Background()); err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if n := summaryCalls.Load(); n != 1 {
+		t.Errorf("MarketSummary probed %d times, want 1", n)
+	}
+	if status, ok := client.Circuits()["MarketSummary"]; ok {
+		t.Errorf("probes should not feed the circuit breakers, got %+v", status)
+	}
+	// The blocked probe does not force a refresh of the token fetched for it
+	if n := tokenCalls.Load(); n != 1 {
+		t.Errorf("expected 1 token fetch, got %d", n)
+	}
+}
+
+func TestClassifyProbe(t *testing.T) {
+	expect := []any{[]LiveMarketEntry{}}
+	tests := []struct {
+		name    string
+		data    string
+		latency time.Duration
+		want    EndpointStatus
+	}{
+		{"working", `[{"symbol":"NABIL","lastTradedPrice":500}]`, time.Millisecond, EndpointWorking},
+		{"slow", `[{"symbol":"NABIL"}]`, DefaultSlowThreshold + time.Second, EndpointSlow},
+		{"empty page", `{"floorsheets":{"content":[],"totalPages":0}}`, 0, EndpointEmpty},
+		{"null", `null`, 0, EndpointEmpty},
+		{"renamed fields", `[{"ticker":"NABIL","ltp":500}]`, 0, EndpointSchemaChanged},
+		{"wrong type", `[{"symbol":42}]`, 0, EndpointSchemaChanged},
+		{"html", `<html></html>`, 0, EndpointSchemaChanged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := classifyProbe([]byte(tt.data), nil, expect, tt.latency); got != tt.want {
+				t.Errorf("classifyProbe = %s (%v), want %s", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -1,17 +1,35 @@
 package nepse
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 )
 
+// maxBodySnippet is the number of response body bytes kept in [NepseError.Body].
+const maxBodySnippet = 256
+
 // NepseError is the error type returned by all NEPSE API operations.
 // Use errors.Is with sentinel errors (e.g., ErrNotFound) to check error categories,
 // or errors.As to extract the full error details.
+//
+// Errors from API requests also record where they happened: the request method
+// and endpoint, the HTTP status, the number of attempts, and what the server
+// said, so failures can be triaged from logs alone. Error includes them, and
+// accessors such as [NepseError.StatusCode] expose them individually.
 type NepseError struct {
 	Type    ErrorType // Category of error
 	Message string    // Human-readable description
 	Err     error     // Underlying error, if any
+
+	method        string
+	endpoint      string
+	statusCode    int
+	attempts      int
+	serverMessage string
+	body          string
 }
 
 // ErrorType categorizes NEPSE errors for programmatic handling.
@@ -26,6 +44,8 @@ const (
 	ErrorTypeNotFound              ErrorType = "not_found"
 	ErrorTypeRateLimit             ErrorType = "rate_limit"
 	ErrorTypeInternal              ErrorType = "internal_error"
+	ErrorTypeCircuitOpen           ErrorType = "circuit_open"
+	ErrorTypeEndpointBlocked       ErrorType = "endpoint_blocked"
 )
 
 // Sentinel errors for use with [errors.Is].
@@ -39,17 +59,47 @@ var (
 	ErrNotFound              = &NepseError{Type: ErrorTypeNotFound}
 	ErrRateLimit             = &NepseError{Type: ErrorTypeRateLimit}
 	ErrInternal              = &NepseError{Type: ErrorTypeInternal}
+	ErrCircuitOpen           = &NepseError{Type: ErrorTypeCircuitOpen}
+	ErrEndpointBlocked       = &NepseError{Type: ErrorTypeEndpointBlocked}
 )
 
 // Error implements the error interface.
+// Request details, when present, follow the message in parentheses, e.g.
+//
+//	nepse: CompanyDetails not found (POST /api/nots/security/999, status 404): Security not found
 func (e *NepseError) Error() string {
-	if e.Message == "" && e.Err == nil {
-		return fmt.Sprintf("nepse: %s", e.Type)
+	var b strings.Builder
+	b.WriteString("nepse: ")
+	switch {
+	case e.Message == "" && e.Err == nil:
+		b.WriteString(string(e.Type))
+	case e.Err != nil:
+		fmt.Fprintf(&b, "%s: %v", e.Message, e.Err)
+	default:
+		b.WriteString(e.Message)
+	}
+
+	var details []string
+	if e.method != "" || e.endpoint != "" {
+		details = append(details, strings.TrimSpace(e.method+" "+e.endpoint))
 	}
-	if e.Err != nil {
-		return fmt.Sprintf("nepse: %s: %v", e.Message, e.Err)
+	if e.statusCode != 0 {
+		details = append(details, fmt.Sprintf("status %d", e.statusCode))
 	}
-	return fmt.Sprintf("nepse: %s", e.Message)
+	if e.attempts > 1 {
+		details = append(details, fmt.Sprintf("after %d attempts", e.attempts))
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+	}
+
+	switch {
+	case e.serverMessage != "":
+		fmt.Fprintf(&b, ": %s", e.serverMessage)
+	case e.body != "":
+		fmt.Fprintf(&b, ": body %q", e.body)
+	}
+	return b.String()
 }
 
 // Unwrap returns the underlying error.
@@ -57,12 +107,48 @@ func (e *NepseError) Unwrap() error {
 	return e.Err
 }
 
+// Method returns the HTTP method of the failed request, or "" if no request was sent.
+func (e *NepseError) Method() string {
+	return e.method
+}
+
+// Endpoint returns the path and query of the failed request, relative to BaseURL.
+func (e *NepseError) Endpoint() string {
+	return e.endpoint
+}
+
+// StatusCode returns the HTTP status of the response, or 0 if none was received.
+func (e *NepseError) StatusCode() int {
+	return e.statusCode
+}
+
+// Attempts returns how many request attempts were made, including retries, or
+// 0 if no request was sent.
+func (e *NepseError) Attempts() int {
+	return e.attempts
+}
+
+// ServerMessage returns the error message from NEPSE's JSON error body, if any.
+func (e *NepseError) ServerMessage() string {
+	return e.serverMessage
+}
+
+// Body returns the start of the raw response body, truncated to 256 bytes.
+func (e *NepseError) Body() string {
+	return e.body
+}
+
 // Is reports whether e matches target by comparing ErrorType fields.
+// A blocked endpoint is a kind of forbidden access, so [ErrEndpointBlocked]
+// errors also match [ErrUnauthorized].
 func (e *NepseError) Is(target error) bool {
 	t, ok := target.(*NepseError)
 	if !ok {
 		return false
 	}
+	if e.Type == ErrorTypeEndpointBlocked && t.Type == ErrorTypeUnauthorized {
+		return true
+	}
 	return e.Type == t.Type
 }
 
@@ -124,6 +210,19 @@ func NewInternalError(message string, err error) *NepseError {
 	return NewNepseError(ErrorTypeInternal, message, err)
 }
 
+// NewCircuitOpenError returns an error for requests rejected because the
+// endpoint's circuit breaker is open.
+func NewCircuitOpenError(endpoint string) *NepseError {
+	return NewNepseError(ErrorTypeCircuitOpen, "circuit open for "+endpoint, nil)
+}
+
+// NewEndpointBlockedError returns an error for an endpoint that keeps answering
+// 403 Forbidden even with a freshly issued token, meaning NEPSE has blocked it
+// server-side rather than rejected the client's credentials.
+func NewEndpointBlockedError(endpoint string) *NepseError {
+	return NewNepseError(ErrorTypeEndpointBlocked, endpoint+" blocked by NEPSE", nil)
+}
+
 // MapHTTPStatusToError converts an HTTP status code to the appropriate NepseError.
 func MapHTTPStatusToError(statusCode int, message string) *NepseError {
 	switch statusCode {
@@ -151,6 +250,55 @@ func MapHTTPStatusToError(statusCode int, message string) *NepseError {
 	}
 }
 
+// newResponseError builds the error for an HTTP error response. resource names
+// what was requested for 404s; body may be a prefix of the full response body.
+func newResponseError(method, endpoint, resource string, statusCode int, status string, body []byte) *NepseError {
+	var e *NepseError
+	if statusCode == http.StatusNotFound {
+		e = NewNotFoundError(resource)
+	} else {
+		e = MapHTTPStatusToError(statusCode, status)
+	}
+	e.method = method
+	e.endpoint = endpoint
+	e.statusCode = statusCode
+	e.serverMessage = parseServerMessage(body)
+	e.body = bodySnippet(body)
+	return e
+}
+
+// serverMessageKeys are the JSON fields NEPSE and its gateway use for error text,
+// in order of preference.
+var serverMessageKeys = []string{"message", "errorMessage", "error_description", "detail", "error", "title"}
+
+// parseServerMessage extracts the error message from a JSON error body, such as
+// Spring's {"status":404,"error":"Not Found","message":"..."}.
+func parseServerMessage(body []byte) string {
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return ""
+	}
+	for _, key := range serverMessageKeys {
+		if msg, ok := fields[key].(string); ok && strings.TrimSpace(msg) != "" {
+			return strings.TrimSpace(msg)
+		}
+	}
+	return ""
+}
+
+// bodySnippet returns body truncated to maxBodySnippet bytes at a rune boundary.
+func bodySnippet(body []byte) string {
+	s := strings.TrimSpace(string(body))
+	if len(s) <= maxBodySnippet {
+		return s
+	}
+	cut := maxBodySnippet
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
+
 // IsRetryable reports whether the operation that caused this error may succeed on retry.
 // Token expiration, network errors, server errors, and rate limits are considered retryable.
 func (e *NepseError) IsRetryable() bool {
@@ -0,0 +1,149 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestNepseError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *NepseError
+		want string
+	}{
+		{"sentinel", ErrNotFound, "nepse: not_found"},
+		{"wrapped", NewNetworkError(errors.New("connection reset")), "nepse: network request failed: connection reset"},
+		{
+			"request details",
+			&NepseError{
+				Type:          ErrorTypeNotFound,
+				Message:       "CompanyDetails not found",
+				method:        http.MethodPost,
+				endpoint:      "/api/nots/security/999",
+				statusCode:    404,
+				attempts:      1,
+				serverMessage: "Security not found",
+			},
+			"nepse: CompanyDetails not found (POST /api/nots/security/999, status 404): Security not found",
+		},
+		{
+			"body without message",
+			&NepseError{
+				Type:       ErrorTypeInvalidServerResponse,
+				Message:    "service unavailable",
+				method:     http.MethodGet,
+				endpoint:   "/api/nots",
+				statusCode: 503,
+				attempts:   4,
+				body:       "<html>maintenance</html>",
+			},
+			`nepse: service unavailable (GET /api/nots, status 503, after 4 attempts): body "<html>maintenance</html>"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q\nwant      %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseServerMessage(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"timestamp":"2026-01-05","status":404,"error":"Not Found","message":"Security not found","path":"/api"}`, "Security not found"},
+		{`{"status":500,"error":"Internal Server Error","message":""}`, "Internal Server Error"},
+		{`{"errorMessage":" Invalid token "}`, "Invalid token"},
+		{`{"message":42}`, ""},
+		{`<html>Bad Gateway</html>`, ""},
+		{``, ""},
+	}
+	for _, tt := range tests {
+		if got := parseServerMessage([]byte(tt.body)); got != tt.want {
+			t.Errorf("parseServerMessage(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestBodySnippet(t *testing.T) {
+	if got := bodySnippet([]byte("  short \n")); got != "short" {
+		t.Errorf("bodySnippet trimmed = %q", got)
+	}
+
+	// Multi-byte runes straddling the limit must not be split
+	long := strings.Repeat("नेप्से", 100)
+	got := bodySnippet([]byte(long))
+	if !strings.HasSuffix(got, "...") || len(got) > maxBodySnippet+3 {
+		t.Errorf("bodySnippet length %d, want at most %d with ellipsis", len(got), maxBodySnippet+3)
+	}
+	if !strings.HasPrefix(long, strings.TrimSuffix(got, "...")) || !utf8.ValidString(got) {
+		t.Errorf("bodySnippet split a rune: %q", got)
+	}
+}
+
+func TestClient_ErrorDetails(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/security/999":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":404,"error":"Not Found","message":"Security not found"}`))
+		case "/api/nots/nepse-data/market-open":
+			w.Write([]byte(`<html>maintenance</html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	_, err = client.Company(ctx, 999)
+	var nepseErr *NepseError
+	if !errors.As(err, &nepseErr) {
+		t.Fatalf("expected *NepseError, got %v", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if nepseErr.Method() != http.MethodGet || nepseErr.Endpoint() != "/api/nots/security/999" ||
+		nepseErr.StatusCode() != http.StatusNotFound || nepseErr.Attempts() != 1 {
+		t.Errorf("unexpected request details: %+v", nepseErr)
+	}
+	if nepseErr.ServerMessage() != "Security not found" || !strings.Contains(nepseErr.Body(), `"status":404`) {
+		t.Errorf("unexpected server details: message %q, body %q", nepseErr.ServerMessage(), nepseErr.Body())
+	}
+	if nepseErr.Message != "CompanyDetails not found" {
+		t.Errorf("Message = %q, want the missing resource", nepseErr.Message)
+	}
+
+	_, err = client.MarketStatus(ctx)
+	if !errors.As(err, &nepseErr) || nepseErr.Body() != "<html>maintenance</html>" || nepseErr.Endpoint() != "/api/nots/nepse-data/market-open" {
+		t.Errorf("decode failure should keep endpoint and body, got %v", err)
+	}
+}
@@ -387,3 +387,37 @@ func TestReport_TypeChecks(t *testing.T) {
 		t.Errorf("expected 'First Quarter', got '%s'", quarterly.QuarterName())
 	}
 }
+
+func TestReport_FiscalPeriod(t *testing.T) {
+	quarterly := Report{
+		FiscalReport: &FiscalReport{
+			ReportTypeMaster: &ReportTypeMaster{ReportName: "Quarterly Report"},
+			QuarterMaster:    &QuarterMaster{QuarterName: "Second Quarter"},
+			FinancialYear:    &FinancialYear{FYName: "2023-2024", FYNameNepali: "2080-2081"},
+		},
+	}
+	fy, q, err := quarterly.FiscalPeriod()
+	if err != nil || fy != 2080 || q != 2 {
+		t.Errorf("FiscalPeriod() = %v %v %v, want 2080/81 Q2", fy, q, err)
+	}
+
+	// Falls back to the Gregorian name
+	annual := Report{
+		FiscalReport: &FiscalReport{
+			ReportTypeMaster: &ReportTypeMaster{ReportName: "Annual Report"},
+			FinancialYear:    &FinancialYear{FYName: "2023-2024"},
+		},
+	}
+	if fy, q, err := annual.FiscalPeriod(); err != nil || fy != 2080 || q != 0 {
+		t.Errorf("annual FiscalPeriod() = %v %v %v, want 2080/81 and no quarter", fy, q, err)
+	}
+
+	if _, _, err := (&Report{}).FiscalPeriod(); err == nil {
+		t.Error("FiscalPeriod() without a financial year should fail")
+	}
+
+	dividend := Dividend{CompanyNews: &CompanyNews{DividendsNotice: &DividendNotice{FinancialYear: &FinancialYear{FYNameNepali: "२०८१/८२"}}}}
+	if fy, err := dividend.BSFiscalYear(); err != nil || fy.String() != "2081/82" {
+		t.Errorf("BSFiscalYear() = %v, %v", fy, err)
+	}
+}
@@ -3,7 +3,8 @@ package nepse
 import (
 	"context"
 	"fmt"
-	"time"
+
+	"github.com/itsbohara/go-nepse/internal/auth"
 )
 
 // dummyData is a static array used by NEPSE's obfuscation algorithm
@@ -39,9 +40,9 @@ func (c *Client) computeBasePayloadID(ctx context.Context) (int, int, error) {
 		}
 	}
 
-	// Get current day of month in Nepal timezone (NEPSE expects NPT)
-	loc, _ := time.LoadLocation("Asia/Kathmandu")
-	day := time.Now().In(loc).Day()
+	// Get current day of month on NEPSE's clock in Nepal timezone, so a
+	// skewed local clock does not pick the wrong day around midnight
+	day := c.authManager.ServerNow().In(NPT).Day()
 
 	// Compute base value: dummyData[dummyID] + dummyID + 2 * day
 	e := dummyData[dummyID] + dummyID + 2*day
@@ -49,31 +50,32 @@ func (c *Client) computeBasePayloadID(ctx context.Context) (int, int, error) {
 	return e, day, nil
 }
 
-// computeIndexGraphPayloadID computes the POST payload ID for index graph endpoints.
-// Uses salt values in addition to the base calculation.
-func (c *Client) computeIndexGraphPayloadID(ctx context.Context) (int, error) {
+// indexGraphPayload returns the POST payload for index graph endpoints.
+// The base value is computed once, but the salt adjustment is recomputed on
+// every attempt because a token refresh between retries rotates the salts.
+func (c *Client) indexGraphPayload(ctx context.Context) (payloadFunc, error) {
 	e, day, err := c.computeBasePayloadID(ctx)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// Get salt values
-	salts, err := c.authManager.GetSalts(ctx)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get salts: %w", err)
-	}
+	return func(ctx context.Context) (any, error) {
+		salts, err := c.authManager.GetSalts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get salts: %w", err)
+		}
+		return graphPostPayload{ID: indexGraphPayloadID(e, day, salts)}, nil
+	}, nil
+}
 
-	// Compute final payload ID using salt values
-	// Logic: if (e % 10 < 5) use salts[3] * day - salts[2], else use salts[1] * day - salts[0]
-	// Python uses 1-indexed array, so: salts[3] = Salt4, salts[1] = Salt2, salts[2] = Salt3, salts[0] = Salt1
-	var payloadID int
+// indexGraphPayloadID applies the salt adjustment to the base payload value e.
+// Logic: if (e % 10 < 5) use salts[3] * day - salts[2], else use salts[1] * day - salts[0]
+// Python uses 1-indexed array, so: salts[3] = Salt4, salts[1] = Salt2, salts[2] = Salt3, salts[0] = Salt1
+func indexGraphPayloadID(e, day int, salts auth.Salts) int {
 	if e%10 < 5 {
-		payloadID = e + salts.Salt4*day - salts.Salt3
-	} else {
-		payloadID = e + salts.Salt2*day - salts.Salt1
+		return e + salts.Salt4*day - salts.Salt3
 	}
-
-	return payloadID, nil
+	return e + salts.Salt2*day - salts.Salt1
 }
 
 // computeScripGraphPayloadID computes the POST payload ID for security/scrip graph endpoints.
@@ -159,13 +161,13 @@ func (c *Client) indexEndpoint(indexType IndexType) string {
 
 // DailyIndexGraph returns intraday graph data points for any market index.
 func (c *Client) DailyIndexGraph(ctx context.Context, indexType IndexType) (*GraphResponse, error) {
-	payloadID, err := c.computeIndexGraphPayloadID(ctx)
+	payload, err := c.indexGraphPayload(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var arr []GraphDataPoint
-	if err := c.apiPostRequest(ctx, c.indexEndpoint(indexType), graphPostPayload{ID: payloadID}, &arr); err != nil {
+	if err := c.apiPostRequest(ctx, c.indexEndpoint(indexType), payload, &arr); err != nil {
 		return nil, err
 	}
 	return &GraphResponse{Data: arr}, nil
@@ -265,7 +267,7 @@ func (c *Client) DailyScripGraph(ctx context.Context, securityID int32) (*GraphR
 
 	endpoint := fmt.Sprintf("%s/%d", c.config.Endpoints.CompanyDailyGraph, securityID)
 	var arr []GraphDataPoint
-	if err := c.apiPostRequest(ctx, endpoint, graphPostPayload{ID: payloadID}, &arr); err != nil {
+	if err := c.apiPostRequest(ctx, endpoint, staticPayload(graphPostPayload{ID: payloadID}), &arr); err != nil {
 		return nil, err
 	}
 	return &GraphResponse{Data: arr}, nil
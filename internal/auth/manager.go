@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"sort"
 	"sync"
@@ -19,6 +20,19 @@ import (
 // mid-request expiration.
 const DefaultTokenTTL = 45 * time.Second
 
+// skewWarnThreshold is how far the local clock may drift from NEPSE's before
+// the manager logs a warning.
+const skewWarnThreshold = 5 * time.Second
+
+// Clock tells the current time, so token expiry can be tested without waiting.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
 // NepseHTTP abstracts HTTP calls needed for token acquisition.
 type NepseHTTP interface {
 	Token(ctx context.Context) (*TokenResponse, error)
@@ -55,26 +69,59 @@ type Manager struct {
 	parser *tokenParser
 
 	maxUpdatePeriod time.Duration
+	clock           Clock
+	logger          *slog.Logger
+	onRefresh       func(time.Duration, error)
 
 	mu          sync.RWMutex
 	accessToken string
 	salts       Salts
-	tokenTS     time.Time
+	tokenTS     time.Time     // Issue time on NEPSE's clock
+	skew        time.Duration // NEPSE's clock minus the local clock
 
 	sf singleflight.Group
 }
 
+// Option configures a Manager.
+type Option func(*Manager)
+
+// WithLogger sets the logger used for token refresh events.
+func WithLogger(l *slog.Logger) Option {
+	return func(m *Manager) { m.logger = l }
+}
+
+// WithClock sets the clock token expiry is measured with; nil keeps the
+// system clock.
+func WithClock(c Clock) Option {
+	return func(m *Manager) {
+		if c != nil {
+			m.clock = c
+		}
+	}
+}
+
+// WithRefreshHook sets a function called after every token refresh attempt
+// with its latency and error (nil on success).
+func WithRefreshHook(fn func(latency time.Duration, err error)) Option {
+	return func(m *Manager) { m.onRefresh = fn }
+}
+
 // NewManager creates a Manager with the embedded WASM token parser.
-func NewManager(httpClient NepseHTTP) (*Manager, error) {
+func NewManager(httpClient NepseHTTP, opts ...Option) (*Manager, error) {
 	parser, err := newTokenParser()
 	if err != nil {
 		return nil, fmt.Errorf("init wasm parser: %w", err)
 	}
-	return &Manager{
+	m := &Manager{
 		http:            httpClient,
 		parser:          parser,
 		maxUpdatePeriod: DefaultTokenTTL,
-	}, nil
+		clock:           systemClock{},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
 }
 
 // Close must be called to release WASM runtime memory.
@@ -136,13 +183,42 @@ func (m *Manager) invalidate() {
 	m.mu.Unlock()
 }
 
+// Skew returns how far NEPSE's clock is ahead of the local clock (negative
+// if behind), as measured at the last token refresh that carried a server
+// time. It is zero until then.
+func (m *Manager) Skew() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.skew
+}
+
+// ServerNow estimates the current time on NEPSE's clock by correcting the
+// local clock for [Manager.Skew].
+func (m *Manager) ServerNow() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.clock.Now().Add(m.skew)
+}
+
+// TokenAge returns how long ago, on NEPSE's clock, the current access token
+// was issued. ok is false when there is no token.
+func (m *Manager) TokenAge() (age time.Duration, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.accessToken == "" || m.tokenTS.IsZero() {
+		return 0, false
+	}
+	return m.clock.Now().Add(m.skew).Sub(m.tokenTS), true
+}
+
 func (m *Manager) isValid() bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	if m.accessToken == "" || m.tokenTS.IsZero() {
 		return false
 	}
-	return time.Since(m.tokenTS) < m.maxUpdatePeriod
+	// tokenTS is on NEPSE's clock, so compare it with NEPSE's time, not ours
+	return m.clock.Now().Add(m.skew).Sub(m.tokenTS) < m.maxUpdatePeriod
 }
 
 func (m *Manager) update(ctx context.Context) error {
@@ -151,13 +227,18 @@ func (m *Manager) update(ctx context.Context) error {
 			return nil, nil
 		}
 
+		start := time.Now()
+		sent := m.clock.Now()
 		resp, err := m.http.Token(ctx)
+		received := m.clock.Now()
 		if err != nil {
+			m.observeRefresh(start, err)
 			return nil, fmt.Errorf("token update: %w", err)
 		}
 
-		access, ts, err := m.parseResponse(*resp)
+		access, err := m.parseResponse(*resp)
 		if err != nil {
+			m.observeRefresh(start, err)
 			return nil, err
 		}
 
@@ -170,28 +251,68 @@ func (m *Manager) update(ctx context.Context) error {
 			Salt4: resp.Salt4,
 			Salt5: resp.Salt5,
 		}
-		if ts > 0 {
-			m.tokenTS = time.Unix(ts, 0)
+		prevSkew := m.skew
+		if resp.ServerTime > 0 {
+			// NEPSE stamped the token somewhere between sending and
+			// receiving; assume the midpoint of the round trip.
+			m.tokenTS = time.UnixMilli(resp.ServerTime)
+			m.skew = m.tokenTS.Sub(sent.Add(received.Sub(sent) / 2))
 		} else {
-			m.tokenTS = time.Now()
+			m.tokenTS = received.Add(m.skew)
 		}
+		skew := m.skew
 		m.mu.Unlock()
 
+		m.observeSkew(prevSkew, skew)
+
+		m.observeRefresh(start, nil)
 		return nil, nil
 	})
 	return err
 }
 
-func (m *Manager) parseResponse(tr TokenResponse) (string, int64, error) {
+// observeRefresh reports the outcome of a token refresh started at start.
+func (m *Manager) observeRefresh(start time.Time, err error) {
+	elapsed := time.Since(start)
+	if m.onRefresh != nil {
+		m.onRefresh(elapsed, err)
+	}
+	if m.logger == nil {
+		return
+	}
+	latency := slog.Duration("latency", elapsed)
+	if err != nil {
+		m.logger.Warn("token refresh failed", latency, slog.Any("error", err))
+		return
+	}
+	m.logger.Debug("token refreshed", latency)
+}
+
+// observeSkew warns when the measured skew first exceeds skewWarnThreshold
+// or moves by more than it since the previous refresh.
+func (m *Manager) observeSkew(prev, skew time.Duration) {
+	if m.logger == nil || absDuration(skew) <= skewWarnThreshold || absDuration(skew-prev) <= skewWarnThreshold {
+		return
+	}
+	m.logger.Warn("local clock differs from NEPSE server time", slog.Duration("skew", skew))
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func (m *Manager) parseResponse(tr TokenResponse) (string, error) {
 	salts := [5]int{tr.Salt1, tr.Salt2, tr.Salt3, tr.Salt4, tr.Salt5}
 
 	idx, err := m.parser.indicesFromSalts(salts)
 	if err != nil {
-		return "", 0, fmt.Errorf("wasm parse: %w", err)
+		return "", fmt.Errorf("wasm parse: %w", err)
 	}
 
-	parsedAccess := sliceSkipAt(tr.AccessToken, idx.access...)
-	return parsedAccess, tr.ServerTime / 1000, nil
+	return sliceSkipAt(tr.AccessToken, idx.access...), nil
 }
 
 // sliceSkipAt strips junk characters inserted by NEPSE's token obfuscation.
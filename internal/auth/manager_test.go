@@ -1,12 +1,17 @@
 package auth
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/itsbohara/go-nepse/nepsetest"
 )
 
 // sliceSkipAt tests - pure unit tests for character stripping logic
@@ -209,6 +214,104 @@ func TestManager_AccessToken_Expiration(t *testing.T) {
 	}
 }
 
+func TestManager_ClockExpiry(t *testing.T) {
+	mock := &mockNepseHTTP{
+		tokenFunc: func(ctx context.Context) (*TokenResponse, error) {
+			// Without a server time the token is stamped with the manager's clock
+			return &TokenResponse{AccessToken: "testXtoken", Salt1: 1234, Salt2: 5678, Salt3: 9012, Salt4: 3456, Salt5: 7890}, nil
+		},
+	}
+	clock := nepsetest.NewClock(time.Date(2026, 1, 5, 11, 0, 0, 0, time.UTC))
+	manager, err := NewManager(mock, WithClock(clock))
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	defer manager.Close()
+
+	ctx := context.Background()
+	steps := []struct {
+		advance   time.Duration
+		wantCalls int32
+	}{
+		{0, 1},
+		{DefaultTokenTTL - time.Second, 1},
+		{time.Second, 2}, // exactly DefaultTokenTTL after the first token
+		{DefaultTokenTTL / 2, 2},
+	}
+	for i, step := range steps {
+		clock.Advance(step.advance)
+		if _, err := manager.AccessToken(ctx); err != nil {
+			t.Fatalf("step %d: AccessToken failed: %v", i, err)
+		}
+		if got := mock.callCount.Load(); got != step.wantCalls {
+			t.Errorf("step %d: %d token requests, want %d", i, got, step.wantCalls)
+		}
+	}
+}
+
+func TestManager_ClockSkew(t *testing.T) {
+	// NEPSE's clock runs a minute ahead of ours
+	clock := nepsetest.NewClock(time.Date(2026, 1, 5, 11, 0, 0, 0, time.UTC))
+	const skew = time.Minute
+	mock := &mockNepseHTTP{
+		tokenFunc: func(ctx context.Context) (*TokenResponse, error) {
+			return &TokenResponse{
+				Salt1:       1234,
+				Salt2:       5678,
+				Salt3:       9012,
+				Salt4:       3456,
+				Salt5:       7890,
+				AccessToken: "testXtoken",
+				ServerTime:  clock.Now().Add(skew).UnixMilli(),
+			}, nil
+		},
+	}
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	manager, err := NewManager(mock, WithClock(clock), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	defer manager.Close()
+
+	if got := manager.Skew(); got != 0 {
+		t.Errorf("Skew before first token = %v, want 0", got)
+	}
+
+	ctx := context.Background()
+	steps := []struct {
+		advance   time.Duration
+		wantCalls int32
+	}{
+		{0, 1},
+		{DefaultTokenTTL - time.Second, 1}, // without compensation this would look 1m44s old
+		{time.Second, 2},
+	}
+	for i, step := range steps {
+		clock.Advance(step.advance)
+		if _, err := manager.AccessToken(ctx); err != nil {
+			t.Fatalf("step %d: AccessToken failed: %v", i, err)
+		}
+		if got := mock.callCount.Load(); got != step.wantCalls {
+			t.Errorf("step %d: %d token requests, want %d", i, got, step.wantCalls)
+		}
+	}
+
+	if got := manager.Skew(); got != skew {
+		t.Errorf("Skew = %v, want %v", got, skew)
+	}
+	if got, want := manager.ServerNow(), clock.Now().Add(skew); !got.Equal(want) {
+		t.Errorf("ServerNow = %v, want %v", got, want)
+	}
+	clock.Advance(10 * time.Second)
+	if age, ok := manager.TokenAge(); !ok || age != 10*time.Second {
+		t.Errorf("TokenAge = %v, %v; want 10s, true", age, ok)
+	}
+	if n := strings.Count(buf.String(), "local clock differs"); n != 1 {
+		t.Errorf("logged %d skew warnings, want 1 for an unchanged skew:\n%s", n, buf.String())
+	}
+}
+
 func TestManager_ForceUpdate(t *testing.T) {
 	var callCount atomic.Int32
 	mock := &mockNepseHTTP{
@@ -535,3 +638,22 @@ func TestManager_ServerTimeHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_WithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	mock := &mockNepseHTTP{}
+	manager, err := NewManager(mock, WithLogger(logger))
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	defer manager.Close()
+
+	if _, err := manager.AccessToken(context.Background()); err != nil {
+		t.Fatalf("AccessToken failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "token refreshed") {
+		t.Errorf("expected token refresh log, got %q", buf.String())
+	}
+}
@@ -0,0 +1,40 @@
+// Package npt provides Nepal Standard Time (UTC+05:45) to the go-nepse
+// packages, so every package agrees on NEPSE's time zone.
+package npt
+
+import (
+	_ "embed"
+	"time"
+)
+
+// Offset is Nepal's offset from UTC. Nepal has not observed daylight saving
+// time since adopting +05:45 in 1986.
+const Offset = 5*time.Hour + 45*time.Minute
+
+//go:embed Asia-Kathmandu.tzif
+var tzdata []byte
+
+// Location is Asia/Kathmandu from the system's time zone database, falling
+// back to a copy embedded in this package and then to a fixed +05:45 zone.
+var Location = load()
+
+func load() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Kathmandu"); err == nil {
+		return loc
+	}
+	if loc, err := time.LoadLocationFromTZData("Asia/Kathmandu", tzdata); err == nil {
+		return loc
+	}
+	return time.FixedZone("NPT", int(Offset/time.Second))
+}
+
+// Date returns midnight in Nepal on the given day.
+func Date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, Location)
+}
+
+// Day truncates t to midnight of its calendar day in Nepal.
+func Day(t time.Time) time.Time {
+	t = t.In(Location)
+	return Date(t.Year(), t.Month(), t.Day())
+}
@@ -0,0 +1,32 @@
+package npt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmbeddedTZData(t *testing.T) {
+	loc, err := time.LoadLocationFromTZData("Asia/Kathmandu", tzdata)
+	if err != nil {
+		t.Fatalf("embedded tzdata: %v", err)
+	}
+	for _, at := range []time.Time{
+		time.Date(2026, 1, 5, 5, 15, 0, 0, time.UTC),
+		time.Date(2026, 7, 5, 5, 15, 0, 0, time.UTC),
+	} {
+		if _, offset := at.In(loc).Zone(); offset != int(Offset/time.Second) {
+			t.Errorf("offset at %v = %ds, want %v", at, offset, Offset)
+		}
+	}
+}
+
+func TestDay(t *testing.T) {
+	// 18:30 UTC is 00:15 the next day in Nepal
+	got := Day(time.Date(2026, 1, 4, 18, 30, 0, 0, time.UTC))
+	if want := Date(2026, 1, 5); !got.Equal(want) {
+		t.Errorf("Day = %v, want %v", got, want)
+	}
+	if _, offset := got.Zone(); offset != int(Offset/time.Second) {
+		t.Errorf("Day offset = %ds, want %v", offset, Offset)
+	}
+}
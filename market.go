@@ -4,8 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"log/slog"
+	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
+
+	"github.com/itsbohara/go-nepse/calendar"
+	"golang.org/x/sync/errgroup"
 )
 
 // Index IDs used by NEPSE API.
@@ -102,10 +110,23 @@ func (c *Client) SubIndices(ctx context.Context) ([]SubIndex, error) {
 
 // LiveMarket returns real-time price and volume data for all actively traded securities.
 func (c *Client) LiveMarket(ctx context.Context) ([]LiveMarketEntry, error) {
+	endpoint := c.config.Endpoints.LiveMarket
+	data, err := c.apiRequestRaw(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	var liveMarket []LiveMarketEntry
-	if err := c.apiRequest(ctx, c.config.Endpoints.LiveMarket, &liveMarket); err != nil {
+	if err := json.Unmarshal(data, &liveMarket); err != nil {
+		return nil, c.decodeError(http.MethodGet, endpoint, data, err)
+	}
+	var decimals []LiveMarketDecimal
+	if err := c.decodeDecimals(http.MethodGet, endpoint, data, &decimals); err != nil {
 		return nil, err
 	}
+	for i := range decimals {
+		liveMarket[i].Decimal = &decimals[i]
+	}
 	return liveMarket, nil
 }
 
@@ -185,8 +206,18 @@ func (c *Client) TopTenTurnover(ctx context.Context) ([]TopTurnoverEntry, error)
 // that requires additional authentication not currently supported by this library.
 // For current prices, consider using [Client.TopGainers], [Client.TopLosers], or
 // [Client.Company] which return LTP (last traded price) data.
+//
+// With [Options.Calendar] set, a businessDate on a weekend or holiday is moved
+// back to the previous trading day.
 func (c *Client) TodaysPrices(ctx context.Context, businessDate string) ([]TodayPrice, error) {
 	endpoint := c.config.Endpoints.TodaysPrice
+	if businessDate != "" && c.options.Calendar != nil {
+		day, err := calendar.Parse(businessDate)
+		if err != nil {
+			return nil, NewInvalidClientRequestError(fmt.Sprintf("invalid business date %q, want YYYY-MM-DD", businessDate))
+		}
+		businessDate = c.options.Calendar.OnOrBefore(day).Format(calendar.DateLayout)
+	}
 	if businessDate != "" {
 		params := url.Values{}
 		params.Set("businessDate", businessDate)
@@ -194,32 +225,170 @@ func (c *Client) TodaysPrices(ctx context.Context, businessDate string) ([]Today
 		endpoint += "?" + params.Encode()
 	}
 
+	data, err := c.apiRequestRaw(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	var todayPrices []TodayPrice
-	if err := c.apiRequest(ctx, endpoint, &todayPrices); err != nil {
+	if err := json.Unmarshal(data, &todayPrices); err != nil {
+		return nil, c.decodeError(http.MethodGet, endpoint, data, err)
+	}
+	var decimals []TodayPriceDecimal
+	if err := c.decodeDecimals(http.MethodGet, endpoint, data, &decimals); err != nil {
 		return nil, err
 	}
+	for i := range decimals {
+		todayPrices[i].Decimal = &decimals[i]
+	}
 	return todayPrices, nil
 }
 
-// PriceHistory returns historical OHLCV data for a security within a date range.
+// priceHistoryChunkDays is the longest date range requested from the price
+// history endpoint at once. NEPSE stops paging long ranges partway through, so
+// multi-year requests are split into ranges of at most this many days.
+const priceHistoryChunkDays = 365
+
+// PriceHistory returns historical OHLCV data for a security within a date range
+// (YYYY-MM-DD, inclusive), sorted by ascending BusinessDate with one entry per day.
+// Long ranges are fetched in yearly chunks and every page of each chunk is read.
+// A range without trading data returns an empty slice and a nil error.
+//
+// With [Options.Calendar] set, the range is narrowed to its first and last
+// trading days and a range without trading days returns without a request.
 func (c *Client) PriceHistory(ctx context.Context, securityID int32, startDate, endDate string) ([]PriceHistory, error) {
-	params := url.Values{}
-	params.Set("size", "500")
-	params.Set("startDate", startDate)
-	params.Set("endDate", endDate)
-	endpoint := fmt.Sprintf("%s/%d?%s", c.config.Endpoints.CompanyPriceHistory, securityID, params.Encode())
+	history, err := collect(c.PriceHistorySeq(ctx, securityID, startDate, endDate))
+	if err != nil {
+		return nil, err
+	}
+	return sortPriceHistory(history), nil
+}
+
+// PriceHistorySeq returns an iterator over historical OHLCV data for a security
+// within a date range, fetching pages of 500 entries as iteration proceeds.
+// Entries are yielded in the order NEPSE returns them, chunk by chunk from the
+// most recent; use [Client.PriceHistory] for a sorted, deduplicated slice.
+func (c *Client) PriceHistorySeq(ctx context.Context, securityID int32, startDate, endDate string) iter.Seq2[PriceHistory, error] {
+	return func(yield func(PriceHistory, error) bool) {
+		startDate, endDate, ok, err := c.tradingRange(startDate, endDate)
+		if err != nil {
+			yield(PriceHistory{}, err)
+			return
+		}
+		if !ok {
+			return
+		}
+
+		chunks, err := splitDateRange(startDate, endDate, priceHistoryChunkDays)
+		if err != nil {
+			yield(PriceHistory{}, err)
+			return
+		}
 
-	var response struct {
-		Content []PriceHistory `json:"content"`
+		for _, chunk := range chunks {
+			params := url.Values{}
+			params.Set("size", "500")
+			params.Set("startDate", chunk[0])
+			params.Set("endDate", chunk[1])
+			endpoint := fmt.Sprintf("%s/%d?%s", c.config.Endpoints.CompanyPriceHistory, securityID, params.Encode())
+
+			pages := paginate(ctx, func(ctx context.Context, p int32) (*PaginatedResponse[PriceHistory], error) {
+				var page PaginatedResponse[PriceHistory]
+				if err := c.apiRequest(ctx, pageEndpoint(endpoint, p), &page); err != nil {
+					return nil, err
+				}
+				return &page, nil
+			})
+			for entry, err := range pages {
+				if !yield(entry, err) || err != nil {
+					return
+				}
+			}
+		}
 	}
+}
 
-	if err := c.apiRequest(ctx, endpoint, &response); err != nil {
-		return nil, err
+// tradingRange narrows [startDate, endDate] to trading days of the configured
+// calendar. It reports false if the range holds no trading day. Without a
+// calendar, or with an open bound, the range is returned unchanged.
+func (c *Client) tradingRange(startDate, endDate string) (string, string, bool, error) {
+	cal := c.options.Calendar
+	if cal == nil || startDate == "" || endDate == "" {
+		return startDate, endDate, true, nil
+	}
+
+	start, err := calendar.Parse(startDate)
+	if err != nil {
+		return "", "", false, NewInvalidClientRequestError(fmt.Sprintf("invalid start date %q, want YYYY-MM-DD", startDate))
+	}
+	end, err := calendar.Parse(endDate)
+	if err != nil {
+		return "", "", false, NewInvalidClientRequestError(fmt.Sprintf("invalid end date %q, want YYYY-MM-DD", endDate))
+	}
+	if end.Before(start) {
+		return "", "", false, NewInvalidClientRequestError(fmt.Sprintf("start date %s is after end date %s", startDate, endDate))
+	}
+
+	first, last := cal.OnOrAfter(start), cal.OnOrBefore(end)
+	if last.Before(first) {
+		return "", "", false, nil
+	}
+	return first.Format(calendar.DateLayout), last.Format(calendar.DateLayout), true, nil
+}
+
+// splitDateRange splits the inclusive range [startDate, endDate] into
+// consecutive [start, end] ranges of at most days days, most recent first.
+// Ranges with an empty bound are passed through unsplit.
+func splitDateRange(startDate, endDate string, days int) ([][2]string, error) {
+	if startDate == "" || endDate == "" {
+		return [][2]string{{startDate, endDate}}, nil
+	}
+
+	start, err := time.Parse(time.DateOnly, startDate)
+	if err != nil {
+		return nil, NewInvalidClientRequestError(fmt.Sprintf("invalid start date %q, want YYYY-MM-DD", startDate))
+	}
+	end, err := time.Parse(time.DateOnly, endDate)
+	if err != nil {
+		return nil, NewInvalidClientRequestError(fmt.Sprintf("invalid end date %q, want YYYY-MM-DD", endDate))
 	}
-	return response.Content, nil
+	if end.Before(start) {
+		return nil, NewInvalidClientRequestError(fmt.Sprintf("start date %s is after end date %s", startDate, endDate))
+	}
+
+	var chunks [][2]string
+	for !end.Before(start) {
+		from := end.AddDate(0, 0, -(days - 1))
+		if from.Before(start) {
+			from = start
+		}
+		chunks = append(chunks, [2]string{from.Format(time.DateOnly), end.Format(time.DateOnly)})
+		end = from.AddDate(0, 0, -1)
+	}
+	return chunks, nil
 }
 
-// PriceHistoryBySymbol returns historical OHLCV data for a security by symbol.
+// sortPriceHistory sorts history by ascending BusinessDate in place and drops
+// repeated business dates, keeping the first entry seen for each.
+func sortPriceHistory(history []PriceHistory) []PriceHistory {
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].BusinessDate.Before(history[j].BusinessDate.Time)
+	})
+
+	deduped := history[:0]
+	for i, entry := range history {
+		if i > 0 && entry.BusinessDate.Equal(history[i-1].BusinessDate.Time) {
+			continue
+		}
+		deduped = append(deduped, entry)
+	}
+	return deduped
+}
+
+// PriceHistoryBySymbol returns historical OHLCV data for a security by symbol,
+// sorted as [Client.PriceHistory]. When endDate is today and NEPSE has not yet
+// published the day's history, the current session is appended from the
+// security details.
 func (c *Client) PriceHistoryBySymbol(ctx context.Context, symbol string, startDate, endDate string) ([]PriceHistory, error) {
 	security, err := c.findSecurityBySymbol(ctx, symbol)
 
@@ -234,24 +403,29 @@ func (c *Client) PriceHistoryBySymbol(ctx context.Context, symbol string, startD
 	}
 
 	// Check if the requested end date is more recent than the available history
-	// assuming first item is the most recent date
-	if endDate > history[0].BusinessDate {
+	var latest Date
+	if len(history) > 0 {
+		latest = history[len(history)-1].BusinessDate
+	}
+	if end, endErr := ParseDate(endDate); endErr == nil && end.After(latest.Time) {
 		// Fetch today's trading data from security details
 		details, err := c.SecurityDetailBySymbol(ctx, symbol)
 
 		if err != nil {
 			// log error but return available history
-			fmt.Printf("warning: requested end date includes today (%s), but failed to fetch latest data for %s: %v\n", endDate, symbol, err)
+			c.logger.Warn("failed to fetch latest data for price history",
+				slog.String("symbol", symbol),
+				slog.String("endDate", endDate),
+				slog.Any("error", err),
+			)
 
 			return history, nil
 		}
 
-		todayDate := details.LastUpdatedDateTime[:10] // YYYY-MM-DD
-
-		if todayDate == endDate {
+		if NewDate(details.LastUpdatedDateTime.Time).Equal(end.Time) {
 			// Append today's data
 			todayPrice := PriceHistory{
-				BusinessDate:        todayDate,
+				BusinessDate:        end,
 				HighPrice:           details.HighPrice,
 				LowPrice:            details.LowPrice,
 				ClosePrice:          details.ClosePrice,
@@ -260,7 +434,7 @@ func (c *Client) PriceHistoryBySymbol(ctx context.Context, symbol string, startD
 				TotalTrades:         details.TotalTrades,
 			}
 
-			history = append([]PriceHistory{todayPrice}, history...)
+			history = append(history, todayPrice)
 		}
 
 	}
@@ -366,10 +540,37 @@ func (c *Client) SecurityDetail(ctx context.Context, securityID int32) (*Securit
 
 	endpoint := fmt.Sprintf("%s/%d", c.config.Endpoints.CompanyDetails, securityID)
 
-	var raw SecurityDetailRaw
-	if err := c.apiPostRequest(ctx, endpoint, graphPostPayload{ID: payloadID}, &raw); err != nil {
+	data, err := c.apiPostRequestRaw(ctx, endpoint, staticPayload(graphPostPayload{ID: payloadID}))
+	if err != nil {
 		return nil, err
 	}
+	var raw SecurityDetailRaw
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, c.decodeError(http.MethodPost, endpoint, data, err)
+	}
+	var decimal *SecurityDetailDecimal
+	if c.options.DecimalPrices {
+		var exact securityDetailDecimalRaw
+		if err := c.decodeDecimals(http.MethodPost, endpoint, data, &exact); err != nil {
+			return nil, err
+		}
+		decimal = &SecurityDetailDecimal{
+			FaceValue:        exact.Security.FaceValue,
+			PaidUpCapital:    exact.PaidUpCapital,
+			IssuedCapital:    exact.IssuedCapital,
+			MarketCap:        exact.MarketCapitalization,
+			OpenPrice:        exact.SecurityDailyTradeDTO.OpenPrice,
+			HighPrice:        exact.SecurityDailyTradeDTO.HighPrice,
+			LowPrice:         exact.SecurityDailyTradeDTO.LowPrice,
+			ClosePrice:       exact.SecurityDailyTradeDTO.ClosePrice,
+			LastTradedPrice:  exact.SecurityDailyTradeDTO.LastTradedPrice,
+			PreviousClose:    exact.SecurityDailyTradeDTO.PreviousClose,
+			FiftyTwoWeekHigh: exact.SecurityDailyTradeDTO.FiftyTwoWeekHigh,
+			FiftyTwoWeekLow:  exact.SecurityDailyTradeDTO.FiftyTwoWeekLow,
+		}
+	}
+
+	c.resolver.recordISIN(raw.Security.ID, raw.Security.Isin)
 
 	return &SecurityDetail{
 		ID:               raw.Security.ID,
@@ -399,6 +600,8 @@ func (c *Client) SecurityDetail(ctx context.Context, securityID int32) (*Securit
 		FiftyTwoWeekLow:     raw.SecurityDailyTradeDTO.FiftyTwoWeekLow,
 		BusinessDate:        raw.SecurityDailyTradeDTO.BusinessDate,
 		LastUpdatedDateTime: raw.SecurityDailyTradeDTO.LastUpdatedDateTime,
+
+		Decimal: decimal,
 	}, nil
 }
 
@@ -420,7 +623,7 @@ func (c *Client) DebugSecurityDetailRaw(ctx context.Context, securityID int32) (
 	}
 
 	endpoint := fmt.Sprintf("%s/%d", c.config.Endpoints.CompanyDetails, securityID)
-	return c.apiPostRequestRaw(ctx, endpoint, graphPostPayload{ID: payloadID})
+	return c.apiPostRequestRaw(ctx, endpoint, staticPayload(graphPostPayload{ID: payloadID}))
 }
 
 // SectorScrips returns a map of sector names to their constituent security symbols.
@@ -458,54 +661,126 @@ func (c *Client) FindSecurityBySymbol(ctx context.Context, symbol string) (*Secu
 }
 
 func (c *Client) findSecurityByID(ctx context.Context, id int32) (*Security, error) {
-	if id <= 0 {
-		return nil, NewInvalidClientRequestError("security ID must be positive")
+	info, err := c.resolver.ResolveID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
+	return &info.Security, nil
+}
 
-	securities, err := c.Securities(ctx)
+func (c *Client) findSecurityBySymbol(ctx context.Context, symbol string) (*Security, error) {
+	info, err := c.resolver.Resolve(ctx, symbol)
 	if err != nil {
 		return nil, err
 	}
+	return &info.Security, nil
+}
 
-	for i := range securities {
-		if securities[i].ID == id {
-			return &securities[i], nil
-		}
-	}
+// DefaultFloorSheetWorkers is the number of floor sheet pages [Client.FloorSheet]
+// fetches in parallel.
+const DefaultFloorSheetWorkers = 4
 
-	return nil, NewNotFoundError(fmt.Sprintf("security with ID %d", id))
+// FloorSheetOptions controls how [Client.FloorSheetWithOptions] fetches pages.
+type FloorSheetOptions struct {
+	// Workers is the maximum number of pages fetched at once after the first.
+	// Values below 2 fetch pages one at a time. Every request still passes
+	// through the client's rate limiter.
+	Workers int
 }
 
-func (c *Client) findSecurityBySymbol(ctx context.Context, symbol string) (*Security, error) {
-	symbol = strings.ToUpper(strings.TrimSpace(symbol))
-	if symbol == "" {
-		return nil, NewInvalidClientRequestError("symbol cannot be empty")
+// FloorSheet returns all trades executed on the exchange for the current trading day.
+// Handles both array and paginated response formats.
+// Note: Returns empty slice if no trades have occurred yet.
+//
+// Pages after the first are fetched in parallel with [DefaultFloorSheetWorkers]
+// workers. A full trading day can hold hundreds of thousands of trades; prefer
+// [Client.FloorSheetSeq] to process them without buffering every page.
+func (c *Client) FloorSheet(ctx context.Context) ([]FloorSheetEntry, error) {
+	return c.FloorSheetWithOptions(ctx, &FloorSheetOptions{Workers: DefaultFloorSheetWorkers})
+}
+
+// FloorSheetWithOptions is like [Client.FloorSheet] with a configurable worker
+// count. Entries keep NEPSE's descending ContractID order regardless of the
+// order pages complete in. The first failing page cancels the remaining
+// requests and its error is returned.
+func (c *Client) FloorSheetWithOptions(ctx context.Context, opts *FloorSheetOptions) ([]FloorSheetEntry, error) {
+	if opts == nil || opts.Workers < 2 {
+		return collect(c.FloorSheetSeq(ctx))
 	}
 
-	securities, err := c.Securities(ctx)
+	endpoint := c.floorSheetEndpoint()
+	first, err := c.floorSheetPage(ctx, endpoint, 0)
 	if err != nil {
 		return nil, err
 	}
-
-	for i := range securities {
-		if securities[i].Symbol == symbol {
-			return &securities[i], nil
+	if first.isLast(0) {
+		return append([]FloorSheetEntry{}, first.Content...), nil
+	}
+	if first.TotalPages <= 0 {
+		// Without a page count the remaining pages can only be walked in order.
+		entries := append([]FloorSheetEntry{}, first.Content...)
+		for entry, err := range paginateFrom(ctx, 1, func(ctx context.Context, p int32) (*PaginatedResponse[FloorSheetEntry], error) {
+			return c.floorSheetPage(ctx, endpoint, p)
+		}) {
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
 		}
+		return entries, nil
+	}
+
+	pages := make([][]FloorSheetEntry, first.TotalPages)
+	pages[0] = first.Content
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(opts.Workers)
+	for p := int32(1); p < first.TotalPages; p++ {
+		g.Go(func() error {
+			page, err := c.floorSheetPage(gctx, endpoint, p)
+			if err != nil {
+				return err
+			}
+			pages[p] = page.Content
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
-	return nil, NewNotFoundError("security with symbol " + symbol)
+	total := 0
+	for _, page := range pages {
+		total += len(page)
+	}
+	entries := make([]FloorSheetEntry, 0, total)
+	for _, page := range pages {
+		entries = append(entries, page...)
+	}
+	return entries, nil
 }
 
-// FloorSheet returns all trades executed on the exchange for the current trading day.
-// Handles both array and paginated response formats.
-// Note: Returns empty slice if no trades have occurred yet.
-func (c *Client) FloorSheet(ctx context.Context) ([]FloorSheetEntry, error) {
+// FloorSheetSeq returns an iterator over the current trading day's trades,
+// newest first. Pages are fetched lazily, so breaking out of the loop stops
+// further requests.
+func (c *Client) FloorSheetSeq(ctx context.Context) iter.Seq2[FloorSheetEntry, error] {
+	endpoint := c.floorSheetEndpoint()
+	return paginate(ctx, func(ctx context.Context, p int32) (*PaginatedResponse[FloorSheetEntry], error) {
+		return c.floorSheetPage(ctx, endpoint, p)
+	})
+}
+
+// floorSheetEndpoint returns the first-page floor sheet endpoint, newest trades first.
+func (c *Client) floorSheetEndpoint() string {
 	params := url.Values{}
 	params.Set("size", "500")
 	params.Set("sort", "contractId,desc")
-	endpoint := c.config.Endpoints.FloorSheet + "?" + params.Encode()
+	return c.config.Endpoints.FloorSheet + "?" + params.Encode()
+}
 
-	data, err := c.apiRequestRaw(ctx, endpoint)
+// floorSheetPage fetches page p of the floor sheet.
+func (c *Client) floorSheetPage(ctx context.Context, endpoint string, p int32) (*PaginatedResponse[FloorSheetEntry], error) {
+	pageURL := pageEndpoint(endpoint, p)
+	data, err := c.apiRequestRaw(ctx, pageURL)
 	if err != nil {
 		return nil, err
 	}
@@ -513,63 +788,78 @@ func (c *Client) FloorSheet(ctx context.Context) ([]FloorSheetEntry, error) {
 	// Try direct array format (may be empty during market hours before trades occur).
 	var floorSheetArray []FloorSheetEntry
 	if err := json.Unmarshal(data, &floorSheetArray); err == nil {
-		return floorSheetArray, nil
+		var decimals []FloorSheetDecimal
+		if err := c.decodeDecimals(http.MethodGet, pageURL, data, &decimals); err != nil {
+			return nil, err
+		}
+		attachFloorSheetDecimals(floorSheetArray, decimals)
+		return &PaginatedResponse[FloorSheetEntry]{Content: floorSheetArray, Last: true}, nil
 	}
 
 	// Try paginated format.
-	var firstPage FloorSheetResponse
-	if err := json.Unmarshal(data, &firstPage); err != nil {
+	var page FloorSheetResponse
+	if err := json.Unmarshal(data, &page); err != nil {
 		return nil, NewInvalidServerResponseError("unrecognized floor sheet response format")
 	}
+	if err := c.decodeFloorSheetDecimals(http.MethodGet, pageURL, data, &page); err != nil {
+		return nil, err
+	}
+	return &page.FloorSheets, nil
+}
 
-	all := firstPage.FloorSheets.Content
-	total := firstPage.FloorSheets.TotalPages
-	for p := int32(1); p < total; p++ {
-		pageEndpoint := fmt.Sprintf("%s&page=%d", endpoint, p)
-		var page FloorSheetResponse
-		if err := c.apiRequest(ctx, pageEndpoint, &page); err != nil {
-			return nil, err
-		}
-		all = append(all, page.FloorSheets.Content...)
+// decodeFloorSheetDecimals attaches exact amounts to a paginated floor sheet
+// response when [Options.DecimalPrices] is set.
+func (c *Client) decodeFloorSheetDecimals(method, endpoint string, data []byte, page *FloorSheetResponse) error {
+	var decimals struct {
+		FloorSheets PaginatedResponse[FloorSheetDecimal] `json:"floorsheets"`
+	}
+	if err := c.decodeDecimals(method, endpoint, data, &decimals); err != nil {
+		return err
+	}
+	attachFloorSheetDecimals(page.FloorSheets.Content, decimals.FloorSheets.Content)
+	return nil
+}
+
+func attachFloorSheetDecimals(entries []FloorSheetEntry, decimals []FloorSheetDecimal) {
+	for i := range decimals {
+		entries[i].Decimal = &decimals[i]
 	}
-	return all, nil
 }
 
 // FloorSheetOf returns all trades for a specific security on a given business date.
 //
 // IMPORTANT: As of December 2025, NEPSE has blocked this endpoint at the server level.
-// All requests return 403 Forbidden. Use [Client.FloorSheet] instead for general floorsheet data.
+// All requests return 403 Forbidden, reported as [ErrEndpointBlocked].
+// Use [Client.FloorSheet] instead for general floorsheet data.
 func (c *Client) FloorSheetOf(ctx context.Context, securityID int32, businessDate string) ([]FloorSheetEntry, error) {
+	return collect(c.FloorSheetOfSeq(ctx, securityID, businessDate))
+}
+
+// FloorSheetOfSeq returns an iterator over a security's trades on a given
+// business date, fetching pages lazily. See [Client.FloorSheetOf] for the
+// endpoint's current availability.
+func (c *Client) FloorSheetOfSeq(ctx context.Context, securityID int32, businessDate string) iter.Seq2[FloorSheetEntry, error] {
 	params := url.Values{}
 	params.Set("businessDate", businessDate)
 	params.Set("size", "500")
 	params.Set("sort", "contractid,desc")
 	endpoint := fmt.Sprintf("%s/%d?%s", c.config.Endpoints.CompanyFloorsheet, securityID, params.Encode())
 
-	var firstPage FloorSheetResponse
-	if err := c.apiRequest(ctx, endpoint, &firstPage); err != nil {
-		return nil, err
-	}
-
-	if len(firstPage.FloorSheets.Content) == 0 {
-		return []FloorSheetEntry{}, nil
-	}
-
-	allEntries := firstPage.FloorSheets.Content
-	totalPages := firstPage.FloorSheets.TotalPages
-
-	for page := int32(1); page < totalPages; page++ {
-		pageEndpoint := fmt.Sprintf("%s&page=%d", endpoint, page)
-
-		var pageResponse FloorSheetResponse
-		if err := c.apiRequest(ctx, pageEndpoint, &pageResponse); err != nil {
+	return paginate(ctx, func(ctx context.Context, p int32) (*PaginatedResponse[FloorSheetEntry], error) {
+		pageURL := pageEndpoint(endpoint, p)
+		data, err := c.apiRequestRaw(ctx, pageURL)
+		if err != nil {
 			return nil, err
 		}
-
-		allEntries = append(allEntries, pageResponse.FloorSheets.Content...)
-	}
-
-	return allEntries, nil
+		var page FloorSheetResponse
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, c.decodeError(http.MethodGet, pageURL, data, err)
+		}
+		if err := c.decodeFloorSheetDecimals(http.MethodGet, pageURL, data, &page); err != nil {
+			return nil, err
+		}
+		return &page.FloorSheets, nil
+	})
 }
 
 // FloorSheetBySymbol returns all trades for a specific security by symbol on a given date.
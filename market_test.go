@@ -0,0 +1,307 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsbohara/go-nepse/calendar"
+)
+
+func TestSplitDateRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end string
+		want       [][2]string
+	}{
+		{"single day", "2026-01-05", "2026-01-05", [][2]string{{"2026-01-05", "2026-01-05"}}},
+		{"one chunk", "2025-01-02", "2026-01-01", [][2]string{{"2025-01-02", "2026-01-01"}}},
+		{
+			"multi year",
+			"2023-06-01", "2026-01-01",
+			[][2]string{
+				{"2025-01-02", "2026-01-01"},
+				{"2024-01-03", "2025-01-01"},
+				{"2023-06-01", "2024-01-02"},
+			},
+		},
+		{"open ended", "", "2026-01-01", [][2]string{{"", "2026-01-01"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitDateRange(tt.start, tt.end, priceHistoryChunkDays)
+			if err != nil {
+				t.Fatalf("splitDateRange failed: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("splitDateRange = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, bad := range [][2]string{{"2026-01-05", "2026-01-04"}, {"05/01/2026", "2026-01-05"}, {"2026-01-01", "2026-13-01"}} {
+		if _, err := splitDateRange(bad[0], bad[1], priceHistoryChunkDays); !errors.Is(err, ErrInvalidClientRequest) {
+			t.Errorf("splitDateRange(%q, %q) error = %v, want ErrInvalidClientRequest", bad[0], bad[1], err)
+		}
+	}
+}
+
+// priceHistoryHandler serves one price history row per calendar day
+// from the requested range, newest first, repeating the last day of every
+// response at the top of the next one as NEPSE does across range boundaries.
+func priceHistoryHandler(requests *[][2]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/security":
+			w.Write([]byte(`[{"id":131,"symbol":"NABIL","activeStatus":"A"}]`))
+		case "/api/nots/company/list":
+			w.Write([]byte(`[{"id":131,"symbol":"NABIL","status":"A"}]`))
+		case "/api/nots/market/history/security/131":
+			q := r.URL.Query()
+			*requests = append(*requests, [2]string{q.Get("startDate"), q.Get("endDate")})
+			start, _ := time.Parse(time.DateOnly, q.Get("startDate"))
+			end, _ := time.Parse(time.DateOnly, q.Get("endDate"))
+
+			page := PaginatedResponse[PriceHistory]{Content: []PriceHistory{}, TotalPages: 1, Last: true}
+			if start.Year() >= 2025 {
+				for d := end.AddDate(0, 0, 1); !d.Before(start); d = d.AddDate(0, 0, -1) {
+					page.Content = append(page.Content, PriceHistory{BusinessDate: NewDate(d)})
+				}
+			}
+			json.NewEncoder(w).Encode(page)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestClient_PriceHistorySortedAndDeduplicated(t *testing.T) {
+	var requests [][2]string
+	client := newTestClient(t, priceHistoryHandler(&requests))
+
+	history, err := client.PriceHistory(context.Background(), 131, "2025-06-01", "2026-09-30")
+	if err != nil {
+		t.Fatalf("PriceHistory failed: %v", err)
+	}
+
+	want := [][2]string{{"2025-10-01", "2026-09-30"}, {"2025-06-01", "2025-09-30"}}
+	if !slices.Equal(requests, want) {
+		t.Errorf("requested ranges %v, want %v", requests, want)
+	}
+
+	// The day repeated at the chunk boundary appears once
+	first, _ := time.Parse(time.DateOnly, "2025-06-01")
+	last, _ := time.Parse(time.DateOnly, "2026-10-01")
+	if wantDays := int(last.Sub(first).Hours()/24) + 1; len(history) != wantDays {
+		t.Fatalf("got %d rows, want %d", len(history), wantDays)
+	}
+	for i := 1; i < len(history); i++ {
+		if !history[i].BusinessDate.After(history[i-1].BusinessDate.Time) {
+			t.Fatalf("history not strictly ascending at %d: %s after %s", i, history[i].BusinessDate, history[i-1].BusinessDate)
+		}
+	}
+	if history[0].BusinessDate.String() != "2025-06-01" {
+		t.Errorf("first row %s, want 2025-06-01", history[0].BusinessDate)
+	}
+}
+
+func TestClient_PriceHistoryEmpty(t *testing.T) {
+	var requests [][2]string
+	client := newTestClient(t, priceHistoryHandler(&requests))
+
+	history, err := client.PriceHistory(context.Background(), 131, "2020-01-01", "2020-12-31")
+	if err != nil || history == nil || len(history) != 0 {
+		t.Errorf("PriceHistory = %v, %v; want an empty slice and no error", history, err)
+	}
+
+	// No history and no security details must not panic
+	history, err = client.PriceHistoryBySymbol(context.Background(), "NABIL", "2020-01-01", "2020-12-31")
+	if err != nil || len(history) != 0 {
+		t.Errorf("PriceHistoryBySymbol = %v, %v; want an empty result", history, err)
+	}
+}
+
+func TestClient_PriceHistoryWithDefaultOptions(t *testing.T) {
+	var requests [][2]string
+	client := newTestClient(t, priceHistoryHandler(&requests), withDefaults)
+
+	for range 2 {
+		history, err := client.PriceHistory(context.Background(), 131, "2025-06-01", "2026-09-30")
+		if err != nil {
+			t.Fatalf("PriceHistory failed: %v", err)
+		}
+		if len(history) != 488 {
+			t.Fatalf("got %d rows, want 488", len(history))
+		}
+	}
+	// Two chunks, and the repeat call is served from the cache
+	if len(requests) != 2 {
+		t.Errorf("requested ranges %v, want two chunks fetched once", requests)
+	}
+}
+
+// floorSheetHandler serves totalPages pages of floor sheet entries with
+// descending contract IDs. Page delays[p], when set, is served after that delay
+// or as soon as the request is cancelled; page failPage answers 500.
+func floorSheetHandler(totalPages int, delays map[int]time.Duration, failPage int, inFlight *atomic.Int32, maxInFlight *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/authenticate/prove" {
+			json.NewEncoder(w).Encode(tokenResponse())
+			return
+		}
+
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		p, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		select {
+		case <-time.After(delays[p]):
+		case <-r.Context().Done():
+			return
+		}
+		if p == failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		page := PaginatedResponse[FloorSheetEntry]{TotalPages: int32(totalPages), Last: p == totalPages-1}
+		for i := range 3 {
+			page.Content = append(page.Content, FloorSheetEntry{ContractID: int64(1000 - p*3 - i)})
+		}
+		json.NewEncoder(w).Encode(FloorSheetResponse{FloorSheets: page})
+	})
+}
+
+func TestClient_FloorSheetConcurrentKeepsOrder(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	// Early pages finish last
+	delays := map[int]time.Duration{1: 80 * time.Millisecond, 2: 40 * time.Millisecond, 3: 20 * time.Millisecond}
+	client := newTestClient(t, floorSheetHandler(8, delays, -1, &inFlight, &maxInFlight))
+
+	entries, err := client.FloorSheetWithOptions(context.Background(), &FloorSheetOptions{Workers: 3})
+	if err != nil {
+		t.Fatalf("FloorSheetWithOptions failed: %v", err)
+	}
+	if len(entries) != 24 {
+		t.Fatalf("got %d entries, want 24", len(entries))
+	}
+	for i, e := range entries {
+		if want := int64(1000 - i); e.ContractID != want {
+			t.Fatalf("entry %d has ContractID %d, want %d", i, e.ContractID, want)
+		}
+	}
+	if m := maxInFlight.Load(); m < 2 || m > 3 {
+		t.Errorf("max concurrent page requests = %d, want 2-3 with 3 workers", m)
+	}
+}
+
+func TestClient_FloorSheetConcurrentCancelsOnError(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	delays := map[int]time.Duration{2: 10 * time.Millisecond, 3: 5 * time.Second, 4: 5 * time.Second}
+	client := newTestClient(t, floorSheetHandler(5, delays, 2, &inFlight, &maxInFlight))
+
+	start := time.Now()
+	_, err := client.FloorSheetWithOptions(context.Background(), &FloorSheetOptions{Workers: 4})
+	if !errors.Is(err, ErrInvalidServerResponse) {
+		t.Fatalf("expected the failing page's error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("slow pages were not cancelled after the first error (took %v)", elapsed)
+	}
+}
+
+func TestClient_FloorSheetWithDefaultOptions(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	client := newTestClient(t, floorSheetHandler(4, nil, -1, &inFlight, &maxInFlight), withDefaults)
+
+	entries, err := client.FloorSheetWithOptions(context.Background(), &FloorSheetOptions{Workers: 3})
+	if err != nil {
+		t.Fatalf("FloorSheetWithOptions failed: %v", err)
+	}
+	if len(entries) != 12 {
+		t.Fatalf("got %d entries, want 12", len(entries))
+	}
+	for i, e := range entries {
+		if want := int64(1000 - i); e.ContractID != want {
+			t.Fatalf("entry %d has ContractID %d, want %d", i, e.ContractID, want)
+		}
+	}
+}
+
+func TestClient_CalendarNormalizesDates(t *testing.T) {
+	var requests [][2]string
+	var businessDates []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/market/history/security/131":
+			q := r.URL.Query()
+			requests = append(requests, [2]string{q.Get("startDate"), q.Get("endDate")})
+			w.Write([]byte(`{"content":[],"totalPages":0}`))
+		case "/api/nots/nepse-data/today-price":
+			businessDates = append(businessDates, r.URL.Query().Get("businessDate"))
+			w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	cal := calendar.Empty()
+	cal.AddHoliday(calendar.Date(2026, time.January, 11), "Prithvi Jayanti")
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+		Calendar: cal,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	// Friday to Sunday holiday narrows to nothing and sends no request
+	if history, err := client.PriceHistory(ctx, 131, "2026-01-09", "2026-01-11"); err != nil || len(history) != 0 {
+		t.Errorf("PriceHistory over a closed weekend = %v, %v", history, err)
+	}
+	if _, err := client.PriceHistory(ctx, 131, "2026-01-10", "2026-01-17"); err != nil {
+		t.Fatalf("PriceHistory failed: %v", err)
+	}
+	if want := [][2]string{{"2026-01-12", "2026-01-15"}}; !slices.Equal(requests, want) {
+		t.Errorf("requested ranges %v, want %v", requests, want)
+	}
+	if _, err := client.PriceHistory(ctx, 131, "2026-01-10", "soon"); !errors.Is(err, ErrInvalidClientRequest) {
+		t.Errorf("expected ErrInvalidClientRequest for a bad date, got %v", err)
+	}
+
+	if _, err := client.TodaysPrices(ctx, "2026-01-11"); err != nil {
+		t.Fatalf("TodaysPrices failed: %v", err)
+	}
+	if want := []string{"2026-01-08"}; !slices.Equal(businessDates, want) {
+		t.Errorf("requested business dates %v, want %v", businessDates, want)
+	}
+}
@@ -0,0 +1,284 @@
+package nepse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics receives instrumentation callbacks from the client.
+// Endpoint labels are [Endpoints] field names (e.g. "LiveMarket"), "Token" for
+// authentication requests, or "Other" for paths outside the configured endpoints,
+// which keeps label cardinality bounded. Implementations must be safe for concurrent use.
+//
+// Embed [NopMetrics] to implement only the callbacks you need.
+type Metrics interface {
+	// RequestStarted is called before each request attempt is sent.
+	RequestStarted(endpoint string, attempt int)
+	// RequestFinished is called after each attempt. status is 0 if no response was received.
+	RequestFinished(endpoint string, status int, duration time.Duration, attempt int)
+	// RequestRetried is called before sleeping ahead of a retry attempt.
+	RequestRetried(endpoint string, attempt int, delay time.Duration)
+	// TokenRefreshed is called after each access token refresh.
+	TokenRefreshed(duration time.Duration, success bool)
+	// CacheHit is called when a response is served from the cache.
+	CacheHit(endpoint string)
+	// CacheMiss is called when a cacheable response is not in the cache.
+	CacheMiss(endpoint string)
+}
+
+// NopMetrics is a [Metrics] implementation that does nothing.
+type NopMetrics struct{}
+
+func (NopMetrics) RequestStarted(string, int)                      {}
+func (NopMetrics) RequestFinished(string, int, time.Duration, int) {}
+func (NopMetrics) RequestRetried(string, int, time.Duration)       {}
+func (NopMetrics) TokenRefreshed(time.Duration, bool)              {}
+func (NopMetrics) CacheHit(string)                                 {}
+func (NopMetrics) CacheMiss(string)                                {}
+
+// endpointLabel returns the bounded metrics label for endpoint.
+func (c *Client) endpointLabel(endpoint string) string {
+	if name := c.config.Endpoints.Name(endpoint); name != "" {
+		return name
+	}
+	if path, _, _ := strings.Cut(endpoint, "?"); path == tokenPath {
+		return "Token"
+	}
+	return "Other"
+}
+
+// DefaultDurationBuckets are the histogram buckets, in seconds, used by [PrometheusMetrics].
+var DefaultDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// PrometheusMetrics is a [Metrics] implementation that renders the Prometheus
+// text exposition format without depending on the Prometheus client library.
+// Serve it directly as an [http.Handler] or write it with [PrometheusMetrics.WriteTo].
+type PrometheusMetrics struct {
+	mu sync.Mutex
+
+	inFlight        map[string]int64
+	requests        map[[2]string]uint64 // endpoint, status
+	requestDuration map[string]*histogram
+	retries         map[string]uint64
+	tokenRefreshes  map[string]uint64 // result
+	tokenDuration   *histogram
+	cache           map[[2]string]uint64 // endpoint, result
+}
+
+// NewPrometheusMetrics creates an empty metrics registry.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		inFlight:        make(map[string]int64),
+		requests:        make(map[[2]string]uint64),
+		requestDuration: make(map[string]*histogram),
+		retries:         make(map[string]uint64),
+		tokenRefreshes:  make(map[string]uint64),
+		tokenDuration:   newHistogram(DefaultDurationBuckets),
+		cache:           make(map[[2]string]uint64),
+	}
+}
+
+// RequestStarted implements [Metrics].
+func (p *PrometheusMetrics) RequestStarted(endpoint string, _ int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight[endpoint]++
+}
+
+// RequestFinished implements [Metrics].
+func (p *PrometheusMetrics) RequestFinished(endpoint string, status int, duration time.Duration, _ int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.inFlight[endpoint]--
+	p.requests[[2]string{endpoint, strconv.Itoa(status)}]++
+	h, ok := p.requestDuration[endpoint]
+	if !ok {
+		h = newHistogram(DefaultDurationBuckets)
+		p.requestDuration[endpoint] = h
+	}
+	h.observe(duration.Seconds())
+}
+
+// RequestRetried implements [Metrics].
+func (p *PrometheusMetrics) RequestRetried(endpoint string, _ int, _ time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.retries[endpoint]++
+}
+
+// TokenRefreshed implements [Metrics].
+func (p *PrometheusMetrics) TokenRefreshed(duration time.Duration, success bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	p.tokenRefreshes[result]++
+	p.tokenDuration.observe(duration.Seconds())
+}
+
+// CacheHit implements [Metrics].
+func (p *PrometheusMetrics) CacheHit(endpoint string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cache[[2]string{endpoint, "hit"}]++
+}
+
+// CacheMiss implements [Metrics].
+func (p *PrometheusMetrics) CacheMiss(endpoint string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cache[[2]string{endpoint, "miss"}]++
+}
+
+// ServeHTTP writes the current metrics in the Prometheus text format.
+func (p *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = p.WriteTo(w)
+}
+
+// WriteTo writes the current metrics in the Prometheus text format.
+func (p *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	cw := &countingWriter{w: bw}
+
+	writeHeader(cw, "nepse_requests_in_flight", "gauge", "Request attempts currently in flight.")
+	for _, ep := range sortedKeys(p.inFlight) {
+		fmt.Fprintf(cw, "nepse_requests_in_flight{endpoint=%s} %d\n", quoteLabel(ep), p.inFlight[ep])
+	}
+
+	writeHeader(cw, "nepse_requests_total", "counter", "Request attempts by endpoint and HTTP status (0 for transport errors).")
+	for _, k := range sortedPairs(p.requests) {
+		fmt.Fprintf(cw, "nepse_requests_total{endpoint=%s,status=%s} %d\n", quoteLabel(k[0]), quoteLabel(k[1]), p.requests[k])
+	}
+
+	writeHeader(cw, "nepse_request_duration_seconds", "histogram", "Request attempt latency.")
+	for _, ep := range sortedKeys(p.requestDuration) {
+		p.requestDuration[ep].write(cw, "nepse_request_duration_seconds", "endpoint="+quoteLabel(ep))
+	}
+
+	writeHeader(cw, "nepse_retries_total", "counter", "Retries scheduled by endpoint.")
+	for _, ep := range sortedKeys(p.retries) {
+		fmt.Fprintf(cw, "nepse_retries_total{endpoint=%s} %d\n", quoteLabel(ep), p.retries[ep])
+	}
+
+	writeHeader(cw, "nepse_token_refreshes_total", "counter", "Access token refreshes by result.")
+	for _, result := range sortedKeys(p.tokenRefreshes) {
+		fmt.Fprintf(cw, "nepse_token_refreshes_total{result=%s} %d\n", quoteLabel(result), p.tokenRefreshes[result])
+	}
+
+	writeHeader(cw, "nepse_token_refresh_duration_seconds", "histogram", "Access token refresh latency.")
+	p.tokenDuration.write(cw, "nepse_token_refresh_duration_seconds", "")
+
+	writeHeader(cw, "nepse_cache_requests_total", "counter", "Cache lookups by endpoint and result.")
+	for _, k := range sortedPairs(p.cache) {
+		fmt.Fprintf(cw, "nepse_cache_requests_total{endpoint=%s,result=%s} %d\n", quoteLabel(k[0]), quoteLabel(k[1]), p.cache[k])
+	}
+
+	if cw.err == nil {
+		cw.err = bw.Flush()
+	}
+	return cw.n, cw.err
+}
+
+// histogram is a cumulative Prometheus histogram.
+type histogram struct {
+	buckets []float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+func (h *histogram) observe(v float64) {
+	for i, upper := range h.buckets {
+		if v <= upper {
+			h.counts[i]++
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+func (h *histogram) write(w io.Writer, name, labels string) {
+	sep := ""
+	if labels != "" {
+		sep = ","
+	}
+	for i, upper := range h.buckets {
+		fmt.Fprintf(w, "%s_bucket{%s%sle=%s} %d\n", name, labels, sep, quoteLabel(strconv.FormatFloat(upper, 'g', -1, 64)), h.counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, sep, h.count)
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labels, h.count)
+}
+
+func writeHeader(w io.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quoteLabel quotes a label value using the Prometheus text format escaping rules.
+func quoteLabel(s string) string {
+	return `"` + labelEscaper.Replace(s) + `"`
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedPairs(m map[[2]string]uint64) [][2]string {
+	keys := make([][2]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
+
+// countingWriter tracks bytes written and the first error for WriteTo.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
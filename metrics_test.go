@@ -0,0 +1,117 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics_WriteTo(t *testing.T) {
+	m := NewPrometheusMetrics()
+	m.RequestStarted("LiveMarket", 1)
+	m.RequestFinished("LiveMarket", 200, 300*time.Millisecond, 1)
+	m.RequestRetried("LiveMarket", 2, time.Second)
+	m.TokenRefreshed(40*time.Millisecond, true)
+	m.CacheHit("LiveMarket")
+	m.CacheMiss(`Odd"Name`)
+
+	var sb strings.Builder
+	n, err := m.WriteTo(&sb)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	out := sb.String()
+	if n != int64(len(out)) {
+		t.Errorf("WriteTo returned %d bytes, wrote %d", n, len(out))
+	}
+
+	for _, want := range []string{
+		"# TYPE nepse_requests_total counter\n",
+		`nepse_requests_in_flight{endpoint="LiveMarket"} 0` + "\n",
+		`nepse_requests_total{endpoint="LiveMarket",status="200"} 1` + "\n",
+		`nepse_request_duration_seconds_bucket{endpoint="LiveMarket",le="0.25"} 0` + "\n",
+		`nepse_request_duration_seconds_bucket{endpoint="LiveMarket",le="0.5"} 1` + "\n",
+		`nepse_request_duration_seconds_bucket{endpoint="LiveMarket",le="+Inf"} 1` + "\n",
+		`nepse_request_duration_seconds_count{endpoint="LiveMarket"} 1` + "\n",
+		`nepse_retries_total{endpoint="LiveMarket"} 1` + "\n",
+		`nepse_token_refreshes_total{result="success"} 1` + "\n",
+		"nepse_token_refresh_duration_seconds_count 1\n",
+		`nepse_cache_requests_total{endpoint="LiveMarket",result="hit"} 1` + "\n",
+		`nepse_cache_requests_total{endpoint="Odd\"Name",result="miss"} 1` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestClient_Metrics(t *testing.T) {
+	var liveCalls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/lives-market":
+			if liveCalls.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			json.NewEncoder(w).Encode([]LiveMarketEntry{})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	metrics := NewPrometheusMetrics()
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  1,
+		RetryDelay:  time.Millisecond,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+		Cache:     NewMemoryCache(DefaultCacheSize),
+		CacheTTLs: map[string]time.Duration{"LiveMarket": time.Minute},
+		Metrics:   metrics,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	for range 2 {
+		if _, err := client.LiveMarket(ctx); err != nil {
+			t.Fatalf("LiveMarket failed: %v", err)
+		}
+	}
+
+	var sb strings.Builder
+	if _, err := metrics.WriteTo(&sb); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	out := sb.String()
+	for _, want := range []string{
+		`nepse_requests_total{endpoint="LiveMarket",status="502"} 1`,
+		`nepse_requests_total{endpoint="LiveMarket",status="200"} 1`,
+		`nepse_requests_total{endpoint="Token",status="200"} 1`,
+		`nepse_requests_in_flight{endpoint="LiveMarket"} 0`,
+		`nepse_retries_total{endpoint="LiveMarket"} 1`,
+		`nepse_token_refreshes_total{result="success"} 1`,
+		`nepse_cache_requests_total{endpoint="LiveMarket",result="miss"} 1`,
+		`nepse_cache_requests_total{endpoint="LiveMarket",result="hit"} 1`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
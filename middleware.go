@@ -0,0 +1,51 @@
+package nepse
+
+import (
+	"context"
+	"net/http"
+)
+
+// RoundTripFunc sends a single HTTP request attempt.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc to observe or modify request attempts.
+// Middleware runs once per attempt, after authentication headers are set and
+// before the request is sent, so retries and token refreshes are visible.
+// Use [RequestInfoFromContext] on the request's context for NEPSE-level details.
+//
+// A Middleware may return an error or synthetic response without calling next
+// (e.g. for fault injection); the client treats it like a transport result.
+// A nil response without an error is reported as an internal error, and a
+// response with a nil Body is given [http.NoBody].
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// RequestInfo describes the NEPSE-level context of a request attempt.
+type RequestInfo struct {
+	Endpoint   string // Path and query relative to BaseURL
+	Name       string // Endpoints field name (e.g. "LiveMarket"); empty for token requests
+	Attempt    int    // 1 for the first attempt, incremented on each retry
+	TokenRetry bool   // True when resent with a refreshed token after a 401 or 403
+}
+
+type requestInfoKey struct{}
+
+// RequestInfoFromContext returns the RequestInfo attached to a request attempt's context.
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+func withRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// chainMiddleware wraps base so that middleware[0] is the outermost layer.
+func chainMiddleware(base RoundTripFunc, middleware []Middleware) RoundTripFunc {
+	rt := base
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if middleware[i] != nil {
+			rt = middleware[i](rt)
+		}
+	}
+	return rt
+}
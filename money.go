@@ -0,0 +1,240 @@
+package nepse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount in Nepalese rupees, stored as a whole number of
+// paisa (1/100 rupee). Sums and products of Money are exact, unlike float64.
+// Values compare with the usual operators.
+type Money int64
+
+// Price is a per-share amount, such as a contract rate or closing price.
+// Multiplying it by a share quantity gives the traded Money.
+type Price = Money
+
+const (
+	Paisa Money = 1
+	Rupee Money = 100
+)
+
+// ParseMoney parses a decimal amount such as "1234.5", "-0.05" or "1.2e3".
+// Digits beyond paisa are rounded half away from zero.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return 0, fmt.Errorf("nepse: invalid amount %q", s)
+		}
+		mantissa, exp = s[:i], e
+	}
+
+	negative := false
+	switch {
+	case strings.HasPrefix(mantissa, "-"):
+		negative, mantissa = true, mantissa[1:]
+	case strings.HasPrefix(mantissa, "+"):
+		mantissa = mantissa[1:]
+	}
+	whole, frac, _ := strings.Cut(mantissa, ".")
+	digits := whole + frac
+	if digits == "" || strings.Trim(digits, "0123456789") != "" || exp > 18 || exp < -18 {
+		return 0, fmt.Errorf("nepse: invalid amount %q", s)
+	}
+
+	// Move the decimal point to paisa and split off the first dropped digit.
+	point := len(whole) + exp + 2
+	var kept string
+	var next byte = '0'
+	switch {
+	case point <= 0:
+		kept = "0"
+		if point == 0 {
+			next = digits[0]
+		}
+	case point >= len(digits):
+		kept = digits + strings.Repeat("0", point-len(digits))
+	default:
+		kept, next = digits[:point], digits[point]
+	}
+
+	var n int64
+	if kept = strings.TrimLeft(kept, "0"); kept != "" {
+		var err error
+		if n, err = strconv.ParseInt(kept, 10, 64); err != nil {
+			return 0, fmt.Errorf("nepse: amount %q out of range", s)
+		}
+	}
+	if next >= '5' {
+		if n == math.MaxInt64 {
+			return 0, fmt.Errorf("nepse: amount %q out of range", s)
+		}
+		n++
+	}
+	if negative {
+		n = -n
+	}
+	return Money(n), nil
+}
+
+// MoneyFromFloat converts a float amount in rupees to Money, rounding to the
+// nearest paisa. It returns 0 for NaN and saturates at the int64 limits.
+func MoneyFromFloat(rupees float64) Money {
+	paisa := math.Round(rupees * 100)
+	switch {
+	case math.IsNaN(paisa):
+		return 0
+	case paisa >= math.MaxInt64:
+		return math.MaxInt64
+	case paisa <= math.MinInt64:
+		return math.MinInt64
+	}
+	return Money(paisa)
+}
+
+// Sum returns the total of amounts.
+func Sum(amounts ...Money) Money {
+	var total Money
+	for _, m := range amounts {
+		total += m
+	}
+	return total
+}
+
+// Add returns m + other.
+func (m Money) Add(other Money) Money { return m + other }
+
+// Sub returns m - other.
+func (m Money) Sub(other Money) Money { return m - other }
+
+// Mul returns m multiplied by n, such as a price times a share quantity.
+func (m Money) Mul(n int64) Money { return m * Money(n) }
+
+// Div returns m divided by n, rounded half away from zero to the nearest
+// paisa. It panics if n is zero.
+func (m Money) Div(n int64) Money {
+	q, r := m/Money(n), m%Money(n)
+	if r < 0 {
+		r = -r
+	}
+	if 2*int64(r) >= absInt64(n) {
+		if (m < 0) != (n < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money { return -m }
+
+// Abs returns the absolute value of m.
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Cmp returns -1, 0 or +1 as m is less than, equal to or greater than other.
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m < other:
+		return -1
+	case m > other:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsZero reports whether m is zero.
+func (m Money) IsZero() bool { return m == 0 }
+
+// Rupees returns the whole rupees in m, truncated toward zero.
+func (m Money) Rupees() int64 { return int64(m / Rupee) }
+
+// Paisa returns the paisa part of m, with the sign of m.
+func (m Money) Paisa() int64 { return int64(m % Rupee) }
+
+// Float64 returns m in rupees as a float64, which may not be exact.
+func (m Money) Float64() float64 { return float64(m) / 100 }
+
+// String formats m in rupees with two decimals, e.g. "1234.50" or "-0.05".
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, absInt64(m.Rupees()), absInt64(m.Paisa()))
+}
+
+// MarshalJSON writes m as a JSON number with two decimals.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or numeric string exactly. Null and ""
+// decode to zero.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = 0
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return m.UnmarshalText([]byte(s))
+	}
+	return m.UnmarshalText(data)
+}
+
+// MarshalText formats m as [Money.String].
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText parses text with [ParseMoney]; empty text is zero.
+func (m *Money) UnmarshalText(text []byte) error {
+	if len(bytes.TrimSpace(text)) == 0 {
+		*m = 0
+		return nil
+	}
+	parsed, err := ParseMoney(string(text))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func absInt64[T ~int64](n T) T {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// decodeDecimals decodes data again into result when [Options.DecimalPrices]
+// is set, and leaves result untouched otherwise.
+func (c *Client) decodeDecimals(method, endpoint string, data []byte, result any) error {
+	if !c.options.DecimalPrices {
+		return nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return c.decodeError(method, endpoint, data, err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Money
+	}{
+		{"0", 0},
+		{"1234.5", 123450},
+		{"1234.56", 123456},
+		{"-0.05", -5},
+		{".5", 50},
+		{"0.004", 0},
+		{"0.005", 1},
+		{"-2.345", -235},
+		{"1.2e3", 120000},
+		{"5E-3", 1},
+		{"123456789012.34", 12345678901234},
+		{"92233720368547758.07", math.MaxInt64},
+		{"92233720368547758.074", math.MaxInt64},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "-", "1.2.3", "12a", "1e", "1e99", "99999999999999999999", "92233720368547758.075", "-92233720368547758.075"} {
+		if _, err := ParseMoney(bad); err == nil {
+			t.Errorf("ParseMoney(%q) should fail", bad)
+		}
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	rate, _ := ParseMoney("512.30")
+	if got := rate.Mul(30); got.String() != "15369.00" {
+		t.Errorf("Mul = %s, want 15369.00", got)
+	}
+	if got := Sum(10, 20, -5).Add(Rupee).Sub(Paisa); got != 124 {
+		t.Errorf("Sum/Add/Sub = %d, want 124", got)
+	}
+	for _, tt := range []struct {
+		m    Money
+		n    int64
+		want Money
+	}{{100, 3, 33}, {200, 3, 67}, {-200, 3, -67}, {5, 2, 3}, {-5, 2, -3}, {5, -2, -3}} {
+		if got := tt.m.Div(tt.n); got != tt.want {
+			t.Errorf("%d.Div(%d) = %d, want %d", tt.m, tt.n, got, tt.want)
+		}
+	}
+	if Money(-5).String() != "-0.05" || Money(-5).Abs() != 5 || Money(3).Cmp(4) != -1 {
+		t.Error("String/Abs/Cmp mismatch")
+	}
+	if MoneyFromFloat(0.1+0.2) != 30 {
+		t.Errorf("MoneyFromFloat(0.1+0.2) = %d, want 30", MoneyFromFloat(0.1+0.2))
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var v struct {
+		Rate   Price `json:"rate"`
+		Amount Money `json:"amount"`
+		Quoted Money `json:"quoted"`
+		Null   Money `json:"null"`
+	}
+	if err := json.Unmarshal([]byte(`{"rate":512.3,"amount":1.0000000000000002e3,"quoted":"0.1","null":null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Rate != 51230 || v.Amount != 100000 || v.Quoted != 10 || v.Null != 0 {
+		t.Errorf("decoded %+v", v)
+	}
+	out, _ := json.Marshal(v)
+	if want := `{"rate":512.30,"amount":1000.00,"quoted":0.10,"null":0.00}`; string(out) != want {
+		t.Errorf("marshal = %s, want %s", out, want)
+	}
+}
+
+func TestClient_DecimalPrices(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/nepse-data/floorsheet":
+			w.Write([]byte(`{"floorsheets":{"content":[
+				{"contractId":3,"contractRate":0.1,"contractQuantity":1,"contractAmount":0.1},
+				{"contractId":2,"contractRate":0.2,"contractQuantity":1,"contractAmount":0.2},
+				{"contractId":1,"contractRate":512.3,"contractQuantity":30,"contractAmount":15369}
+			],"totalPages":1,"number":0,"last":true}}`))
+		case "/api/nots/lives-market":
+			w.Write([]byte(`[{"securityId":"131","symbol":"NABIL","lastTradedPrice":512.3,"averageTradedPrice":510.456}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	newClient := func(decimal bool) *Client {
+		client, err := NewClient(&Options{
+			BaseURL:       server.URL,
+			HTTPTimeout:   5 * time.Second,
+			MaxRetries:    0,
+			Config:        &Config{BaseURL: server.URL, Endpoints: DefaultEndpoints()},
+			DecimalPrices: decimal,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	entries, err := newClient(false).FloorSheetWithOptions(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("FloorSheet failed: %v", err)
+	}
+	if entries[0].Decimal != nil {
+		t.Error("Decimal should be nil without DecimalPrices")
+	}
+
+	client := newClient(true)
+	entries, err = client.FloorSheetWithOptions(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("FloorSheet failed: %v", err)
+	}
+	var total Money
+	for _, e := range entries {
+		if e.Decimal == nil {
+			t.Fatalf("contract %d has no Decimal", e.ContractID)
+		}
+		total += e.Decimal.ContractAmount
+	}
+	if total.String() != "15369.30" {
+		t.Errorf("total = %s, want 15369.30", total)
+	}
+	if got := entries[2].Decimal.ContractRate.Mul(entries[2].ContractQuantity); got != entries[2].Decimal.ContractAmount {
+		t.Errorf("rate × quantity = %s, want %s", got, entries[2].Decimal.ContractAmount)
+	}
+
+	live, err := client.LiveMarket(context.Background())
+	if err != nil {
+		t.Fatalf("LiveMarket failed: %v", err)
+	}
+	if d := live[0].Decimal; d == nil || d.LastTradedPrice != 51230 || d.AverageTradedPrice != 51046 {
+		t.Errorf("LiveMarket Decimal = %+v", d)
+	}
+}
@@ -0,0 +1,242 @@
+// Package npr formats and parses amounts the way they are written in Nepal:
+// South Asian digit grouping ("4,52,31,000"), lakh/crore/arab/kharab units
+// ("45.23 Crore"), the "Rs." prefix, and optionally Devanagari numerals.
+//
+// Only the last three integer digits form a group; every group before them has
+// two digits. Formatting rounds with [strconv.FormatFloat].
+package npr
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/itsbohara/go-nepse/bs"
+)
+
+// Unit is a scale amounts can be written in.
+type Unit int
+
+const (
+	NoUnit Unit = iota // Plain grouped digits
+	Auto               // The largest unit the amount reaches, or NoUnit below a lakh
+	Lakh               // 1,00,000
+	Crore              // 1,00,00,000
+	Arab               // 1,00,00,00,000
+	Kharab             // 1,00,00,00,00,000
+)
+
+var unitValues = map[Unit]float64{Lakh: 1e5, Crore: 1e7, Arab: 1e9, Kharab: 1e11}
+
+var unitNames = map[Unit][2]string{
+	Lakh:   {"Lakh", "लाख"},
+	Crore:  {"Crore", "करोड"},
+	Arab:   {"Arab", "अर्ब"},
+	Kharab: {"Kharab", "खर्ब"},
+}
+
+// String returns the unit's English name, e.g. "Crore".
+func (u Unit) String() string {
+	switch u {
+	case NoUnit:
+		return ""
+	case Auto:
+		return "auto"
+	}
+	if names, ok := unitNames[u]; ok {
+		return names[0]
+	}
+	return "Unit(" + strconv.Itoa(int(u)) + ")"
+}
+
+// Nepali returns the unit's name in Devanagari, e.g. "करोड".
+func (u Unit) Nepali() string {
+	if names, ok := unitNames[u]; ok {
+		return names[1]
+	}
+	return u.String()
+}
+
+// Value returns the number of rupees in one u, or 1 for NoUnit and Auto.
+func (u Unit) Value() float64 {
+	if v, ok := unitValues[u]; ok {
+		return v
+	}
+	return 1
+}
+
+// Options controls how an amount is formatted. The zero value writes grouped
+// whole numbers in Latin digits without a currency symbol.
+type Options struct {
+	Decimals   int  // Digits after the decimal point
+	Unit       Unit // Scale to write the amount in
+	Currency   bool // Prefix "Rs. " ("रु. " with Devanagari)
+	Devanagari bool // Devanagari digits and unit names
+}
+
+// Format formats v according to opts; nil opts uses the zero Options.
+func Format(v float64, opts *Options) string {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	unit := o.Unit
+	if unit == Auto {
+		unit = autoUnit(math.Abs(v))
+	}
+	digits := scale(v, unit, o.Decimals)
+	if o.Unit == Auto {
+		// Rounding can reach the next unit: 99.999 Lakh is 1.00 Crore.
+		if rounded, _ := strconv.ParseFloat(digits, 64); autoUnit(rounded*unit.Value()) != unit {
+			unit = autoUnit(rounded * unit.Value())
+			digits = scale(v, unit, o.Decimals)
+		}
+	}
+
+	var b strings.Builder
+	if v < 0 && strings.Trim(digits, "0.") != "" {
+		b.WriteByte('-')
+	}
+	if o.Currency {
+		b.WriteString(symbol(o.Devanagari))
+	}
+	b.WriteString(Group(digits))
+	if unit != NoUnit {
+		b.WriteByte(' ')
+		if o.Devanagari {
+			b.WriteString(unit.Nepali())
+		} else {
+			b.WriteString(unit.String())
+		}
+	}
+	if o.Devanagari {
+		return bs.ToDevanagari(b.String())
+	}
+	return b.String()
+}
+
+func autoUnit(v float64) Unit {
+	for _, u := range []Unit{Kharab, Arab, Crore, Lakh} {
+		if v >= u.Value() {
+			return u
+		}
+	}
+	return NoUnit
+}
+
+// scale returns |v| in unit with the given decimals.
+func scale(v float64, unit Unit, decimals int) string {
+	return strconv.FormatFloat(math.Abs(v)/unit.Value(), 'f', max(decimals, 0), 64)
+}
+
+func symbol(devanagari bool) string {
+	if devanagari {
+		return "रु. "
+	}
+	return "Rs. "
+}
+
+// Group inserts South Asian grouping commas into a plain decimal number such
+// as "45231000.5", giving "4,52,31,000.5". A leading '-' is kept.
+func Group(number string) string {
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+	whole, frac, hasFrac := strings.Cut(number, ".")
+	if len(whole) > 3 {
+		head, tail := whole[:len(whole)-3], whole[len(whole)-3:]
+		var groups []string
+		for len(head) > 2 {
+			groups = append([]string{head[len(head)-2:]}, groups...)
+			head = head[:len(head)-2]
+		}
+		whole = strings.Join(append(append([]string{head}, groups...), tail), ",")
+	}
+	if hasFrac {
+		return sign + whole + "." + frac
+	}
+	return sign + whole
+}
+
+// Rupees formats v as "Rs. 4,52,31,000" with the given number of decimals.
+func Rupees(v float64, decimals int) string {
+	return Format(v, &Options{Decimals: decimals, Currency: true})
+}
+
+// Short formats v in the largest unit it reaches, e.g. "45.23 Crore".
+func Short(v float64, decimals int) string {
+	return Format(v, &Options{Decimals: decimals, Unit: Auto})
+}
+
+// Parse parses an amount written by [Format] or by hand: an optional sign,
+// an optional "Rs.", "Rs", "NPR" or "रु." prefix, digits in either script
+// with any grouping commas, and an optional unit such as "Crore", "Cr",
+// "Lakhs", "Lac", "Arba" or "करोड".
+func Parse(s string) (float64, error) {
+	text := strings.TrimSpace(bs.ToLatin(s))
+	negative := false
+	if strings.HasPrefix(text, "-") {
+		negative, text = true, strings.TrimSpace(text[1:])
+	}
+	for _, prefix := range []string{"rs.", "rs", "npr", "रु.", "रु"} {
+		if len(text) >= len(prefix) && strings.EqualFold(text[:len(prefix)], prefix) {
+			text = strings.TrimSpace(text[len(prefix):])
+			break
+		}
+	}
+	if !negative && strings.HasPrefix(text, "-") {
+		negative, text = true, strings.TrimSpace(text[1:])
+	}
+
+	number, unit := text, NoUnit
+	if i := strings.IndexFunc(text, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r == '.' || r == ',')
+	}); i >= 0 {
+		number = strings.TrimSpace(text[:i])
+		var ok bool
+		if unit, ok = parseUnit(strings.TrimSpace(text[i:])); !ok {
+			return 0, fmt.Errorf("npr: unknown unit in %q", s)
+		}
+	}
+
+	v, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+	if err != nil || number == "" {
+		return 0, fmt.Errorf("npr: invalid amount %q", s)
+	}
+	v *= unit.Value()
+	if negative {
+		v = -v
+	}
+	return v, nil
+}
+
+// ParseUnit parses a unit name in English or Nepali, singular or plural,
+// or a common abbreviation.
+func ParseUnit(name string) (Unit, error) {
+	if u, ok := parseUnit(strings.TrimSpace(name)); ok {
+		return u, nil
+	}
+	return NoUnit, fmt.Errorf("npr: unknown unit %q", name)
+}
+
+func parseUnit(name string) (Unit, bool) {
+	switch strings.TrimSuffix(strings.ToLower(name), ".") {
+	case "":
+		return NoUnit, true
+	case "lakh", "lakhs", "lac", "lacs", "l", "लाख":
+		return Lakh, true
+	case "crore", "crores", "cr", "करोड", "करोड़":
+		return Crore, true
+	case "arab", "arabs", "arba", "arb", "अर्ब", "अरब":
+		return Arab, true
+	case "kharab", "kharabs", "kharba", "खर्ब", "खरब":
+		return Kharab, true
+	}
+	return NoUnit, false
+}
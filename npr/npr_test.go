@@ -0,0 +1,106 @@
+package npr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	tests := map[string]string{
+		"0":             "0",
+		"999":           "999",
+		"1000":          "1,000",
+		"100000":        "1,00,000",
+		"45231000":      "4,52,31,000",
+		"45231000.5":    "4,52,31,000.5",
+		"-1234567.89":   "-12,34,567.89",
+		"1000000000000": "10,00,00,00,00,000",
+	}
+	for in, want := range tests {
+		if got := Group(in); got != want {
+			t.Errorf("Group(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		v    float64
+		opts *Options
+		want string
+	}{
+		{45231000, nil, "4,52,31,000"},
+		{45231000, &Options{Currency: true}, "Rs. 4,52,31,000"},
+		{452310000, &Options{Unit: Auto, Decimals: 2}, "45.23 Crore"},
+		{452310000, &Options{Unit: Lakh, Decimals: 1}, "4,523.1 Lakh"},
+		{1.5e9, &Options{Unit: Auto, Decimals: 2}, "1.50 Arab"},
+		{2.5e12, &Options{Unit: Auto}, "25 Kharab"},
+		{99999, &Options{Unit: Auto}, "99,999"},
+		{9999999.999, &Options{Unit: Auto, Decimals: 2}, "1.00 Crore"},
+		{-1234.5, &Options{Decimals: 2, Currency: true}, "-Rs. 1,234.50"},
+		{-0.001, &Options{Decimals: 2}, "0.00"},
+		{452310000, &Options{Unit: Auto, Decimals: 2, Currency: true, Devanagari: true}, "रु. ४५.२३ करोड"},
+		{45231000, &Options{Devanagari: true}, "४,५२,३१,०००"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.v, tt.opts); got != tt.want {
+			t.Errorf("Format(%v, %+v) = %q, want %q", tt.v, tt.opts, got, tt.want)
+		}
+	}
+	if got := Rupees(1234567.891, 2); got != "Rs. 12,34,567.89" {
+		t.Errorf("Rupees = %q", got)
+	}
+	if got := Short(250000, 1); got != "2.5 Lakh" {
+		t.Errorf("Short = %q", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]float64{
+		"4,52,31,000":     45231000,
+		"Rs. 4,52,31,000": 45231000,
+		"rs 1,234.50":     1234.5,
+		"NPR 1000":        1000,
+		"45.23 Crore":     452300000,
+		"45.23cr":         452300000,
+		"2.5 Lakhs":       250000,
+		"3 lac":           300000,
+		"1.5 Arba":        1.5e9,
+		"-Rs. 1,000":      -1000,
+		"Rs. -1,000":      -1000,
+		"रु. ४५.२३ करोड":  452300000,
+		"४,५२,३१,०००":     45231000,
+		"2 खर्ब":          2e11,
+	}
+	for in, want := range tests {
+		got, err := Parse(in)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", in, err)
+			continue
+		}
+		if math.Abs(got-want) > 1e-6 {
+			t.Errorf("Parse(%q) = %v, want %v", in, got, want)
+		}
+	}
+	for _, bad := range []string{"", "Rs.", "12 dozen", "1.2.3", "abc"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	for _, v := range []float64{0, 12.5, 45231000, 1.5e9, -987654.32} {
+		for _, opts := range []*Options{
+			{Decimals: 2, Currency: true},
+			{Decimals: 2, Devanagari: true, Currency: true},
+			{Decimals: 7, Unit: Auto},
+		} {
+			s := Format(v, opts)
+			got, err := Parse(s)
+			if err != nil || math.Abs(got-v) > 0.005 {
+				t.Errorf("Parse(Format(%v)) = %q -> %v, %v", v, s, got, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package nepse
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// pageFetcher returns page p (0-based) of a paginated endpoint.
+type pageFetcher[T any] func(ctx context.Context, p int32) (*PaginatedResponse[T], error)
+
+// paginate lazily yields every item of a paginated endpoint, fetching the next
+// page only once the consumer has ranged over the previous one. Iteration ends
+// after the last page, when the consumer breaks, or after yielding an error.
+func paginate[T any](ctx context.Context, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	return paginateFrom(ctx, 0, fetch)
+}
+
+// paginateFrom is like paginate but starts at page first.
+func paginateFrom[T any](ctx context.Context, first int32, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for p := first; ; p++ {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			page, err := fetch(ctx, p)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Content {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if page.isLast(p) {
+				return
+			}
+		}
+	}
+}
+
+// isLast reports whether p, the page just fetched, is the final page. Pages
+// that report neither Last nor TotalPages end at the first empty page.
+func (r *PaginatedResponse[T]) isLast(p int32) bool {
+	return r.Last || len(r.Content) == 0 || (r.TotalPages > 0 && p+1 >= r.TotalPages)
+}
+
+// pageEndpoint appends the page parameter to endpoint, which must already have a query.
+// The first page is requested without it, matching NEPSE's web client.
+func pageEndpoint(endpoint string, p int32) string {
+	if p == 0 {
+		return endpoint
+	}
+	return fmt.Sprintf("%s&page=%d", endpoint, p)
+}
+
+// collect drains seq into a slice, stopping at the first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
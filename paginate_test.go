@@ -0,0 +1,158 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// paginatedHandler serves totalPages pages of perPage floor sheet entries
+// and price history rows, recording the page numbers requested per path.
+func paginatedHandler(totalPages, perPage int, failPage int) (http.Handler, func(path string) []int) {
+	var mu sync.Mutex
+	requested := make(map[string][]int)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/authenticate/prove" {
+			json.NewEncoder(w).Encode(tokenResponse())
+			return
+		}
+
+		p, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		mu.Lock()
+		requested[r.URL.Path] = append(requested[r.URL.Path], p)
+		mu.Unlock()
+		if p == failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/nots/nepse-data/floorsheet":
+			page := PaginatedResponse[FloorSheetEntry]{TotalPages: int32(totalPages), PageNumber: int32(p), Last: p == totalPages-1}
+			for i := range perPage {
+				page.Content = append(page.Content, FloorSheetEntry{ContractID: int64(1000 - p*perPage - i)})
+			}
+			json.NewEncoder(w).Encode(FloorSheetResponse{FloorSheets: page})
+		case "/api/nots/market/history/security/131":
+			page := PaginatedResponse[PriceHistory]{TotalPages: int32(totalPages), PageNumber: int32(p)}
+			for i := range perPage {
+				day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(p*perPage + i))
+				page.Content = append(page.Content, PriceHistory{BusinessDate: NewDate(day)})
+			}
+			json.NewEncoder(w).Encode(page)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	return handler, func(path string) []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), requested[path]...)
+	}
+}
+
+func TestClient_FloorSheetSeqStopsOnBreak(t *testing.T) {
+	handler, requested := paginatedHandler(5, 2, -1)
+	client := newTestClient(t, handler)
+
+	var got []int64
+	for entry, err := range client.FloorSheetSeq(context.Background()) {
+		if err != nil {
+			t.Fatalf("FloorSheetSeq failed: %v", err)
+		}
+		got = append(got, entry.ContractID)
+		if len(got) == 3 {
+			break
+		}
+	}
+
+	if want := []int64{1000, 999, 998}; !slices.Equal(got, want) {
+		t.Errorf("entries = %v, want %v", got, want)
+	}
+	// Breaking on the first entry of page 1 must not fetch page 2
+	if pages := requested("/api/nots/nepse-data/floorsheet"); !slices.Equal(pages, []int{0, 1}) {
+		t.Errorf("requested pages %v, want [0 1]", pages)
+	}
+}
+
+func TestClient_FloorSheetCollectsAllPages(t *testing.T) {
+	handler, requested := paginatedHandler(3, 4, -1)
+	client := newTestClient(t, handler)
+
+	entries, err := client.FloorSheet(context.Background())
+	if err != nil {
+		t.Fatalf("FloorSheet failed: %v", err)
+	}
+	if len(entries) != 12 {
+		t.Errorf("got %d entries, want 12", len(entries))
+	}
+	// Pages after the first are fetched concurrently, so only the set is fixed
+	pages := requested("/api/nots/nepse-data/floorsheet")
+	slices.Sort(pages)
+	if !slices.Equal(pages, []int{0, 1, 2}) {
+		t.Errorf("requested pages %v, want [0 1 2]", pages)
+	}
+}
+
+func TestClient_PriceHistoryFetchesEveryPage(t *testing.T) {
+	handler, requested := paginatedHandler(3, 500, -1)
+	client := newTestClient(t, handler)
+
+	history, err := client.PriceHistory(context.Background(), 131, "2025-01-02", "2026-01-01")
+	if err != nil {
+		t.Fatalf("PriceHistory failed: %v", err)
+	}
+	if len(history) != 1500 {
+		t.Errorf("got %d rows, want 1500 (no truncation at the page size)", len(history))
+	}
+	if pages := requested("/api/nots/market/history/security/131"); !slices.Equal(pages, []int{0, 1, 2}) {
+		t.Errorf("requested pages %v, want [0 1 2]", pages)
+	}
+}
+
+func TestClient_PaginatedSeqYieldsPageError(t *testing.T) {
+	handler, _ := paginatedHandler(3, 2, 1)
+	client := newTestClient(t, handler)
+
+	var n int
+	var lastErr error
+	for _, err := range client.PriceHistorySeq(context.Background(), 131, "2025-01-01", "2026-01-01") {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		n++
+	}
+	if n != 2 || !errors.Is(lastErr, ErrInvalidServerResponse) {
+		t.Errorf("got %d rows and error %v; want 2 rows then a server error", n, lastErr)
+	}
+
+	if _, err := client.PriceHistory(context.Background(), 131, "2025-01-01", "2026-01-01"); err == nil {
+		t.Error("PriceHistory should fail when a page fails")
+	}
+}
+
+func TestClient_FloorSheetSeqWithDefaultOptions(t *testing.T) {
+	handler, requested := paginatedHandler(3, 2, -1)
+	client := newTestClient(t, handler, withDefaults)
+
+	var got int
+	for _, err := range client.FloorSheetSeq(context.Background()) {
+		if err != nil {
+			t.Fatalf("FloorSheetSeq failed: %v", err)
+		}
+		got++
+	}
+	if pages := requested("/api/nots/nepse-data/floorsheet"); got != 6 || !slices.Equal(pages, []int{0, 1, 2}) {
+		t.Errorf("got %d entries from pages %v, want 6 from [0 1 2]", got, pages)
+	}
+}
@@ -0,0 +1,228 @@
+package nepse
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Adaptive rate limiting parameters. After adaptAfter consecutive rate-limit
+// responses the rate is halved (down to the configured floor); every
+// recoverAfter successful requests it climbs back by a tenth of the base rate.
+const (
+	adaptAfter     = 2
+	recoverAfter   = 20
+	recoverFactor  = 0.1
+	backoffFactor  = 0.5
+	defaultMinRate = 0.1
+)
+
+// RateLimit configures client-side request pacing with a token bucket.
+// Requests wait for a token before being sent, blocking until one is available
+// or the request context is done.
+type RateLimit struct {
+	RequestsPerSecond float64 // Sustained request rate; zero or negative disables limiting
+	Burst             int     // Requests that may be sent back-to-back; values below 1 mean 1
+
+	// MinRequestsPerSecond is the floor the rate adapts down to after repeated
+	// rate-limit responses. Zero uses 0.1 requests per second.
+	MinRequestsPerSecond float64
+
+	// Endpoints overrides the limit for individual endpoints, keyed by Endpoints
+	// field name (e.g. "FloorSheet"). Overridden endpoints use their own bucket
+	// instead of the shared one.
+	Endpoints map[string]RateLimit
+}
+
+// DefaultRateLimit returns a conservative limit suitable for NEPSE's public API:
+// 5 requests per second with a burst of 5. Limiting is opt-in; assign it to
+// [Options.RateLimit] to enable it.
+func DefaultRateLimit() *RateLimit {
+	return &RateLimit{
+		RequestsPerSecond: 5,
+		Burst:             5,
+	}
+}
+
+// rateLimiter holds the shared bucket and any per-endpoint buckets.
+type rateLimiter struct {
+	shared    *tokenBucket
+	endpoints map[string]*tokenBucket
+}
+
+// newRateLimiter returns nil if cfg is nil or disabled.
+func newRateLimiter(cfg *RateLimit) *rateLimiter {
+	if cfg == nil || cfg.RequestsPerSecond <= 0 {
+		return nil
+	}
+	l := &rateLimiter{
+		shared:    newTokenBucket(*cfg),
+		endpoints: make(map[string]*tokenBucket, len(cfg.Endpoints)),
+	}
+	for name, override := range cfg.Endpoints {
+		if override.RequestsPerSecond > 0 {
+			l.endpoints[name] = newTokenBucket(override)
+		}
+	}
+	return l
+}
+
+// bucket returns the bucket governing the named endpoint.
+func (l *rateLimiter) bucket(name string) *tokenBucket {
+	if b, ok := l.endpoints[name]; ok {
+		return b
+	}
+	return l.shared
+}
+
+// tokenBucket is a token bucket whose refill rate adapts to rate-limit responses.
+type tokenBucket struct {
+	mu          sync.Mutex
+	base        float64 // configured rate
+	rate        float64 // current rate
+	floor       float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+
+	limited   int // consecutive rate-limit responses
+	successes int // successes since the last adjustment
+}
+
+func newTokenBucket(cfg RateLimit) *tokenBucket {
+	burst := float64(max(cfg.Burst, 1))
+	floor := cfg.MinRequestsPerSecond
+	if floor <= 0 {
+		floor = defaultMinRate
+	}
+	return &tokenBucket{
+		base:   cfg.RequestsPerSecond,
+		rate:   cfg.RequestsPerSecond,
+		floor:  min(floor, cfg.RequestsPerSecond),
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.refill(now)
+
+		var delay time.Duration
+		switch {
+		case now.Before(b.pausedUntil):
+			delay = b.pausedUntil.Sub(now)
+		case b.tokens >= 1:
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		default:
+			delay = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		}
+		b.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// onRateLimited records a rate-limit response. The bucket is paused for
+// retryAfter, if given, and the rate is reduced after repeated responses.
+func (b *tokenBucket) onRateLimited(retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refill(now)
+	b.successes = 0
+	b.limited++
+	if b.limited >= adaptAfter {
+		b.rate = max(b.floor, b.rate*backoffFactor)
+		b.tokens = min(b.tokens, 0)
+	}
+	if retryAfter > 0 {
+		if until := now.Add(retryAfter); until.After(b.pausedUntil) {
+			b.pausedUntil = until
+		}
+	}
+}
+
+// onSuccess records a request that was not rate limited and slowly restores the rate.
+func (b *tokenBucket) onSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.limited = 0
+	if b.rate >= b.base {
+		return
+	}
+	b.successes++
+	if b.successes >= recoverAfter {
+		b.refill(time.Now())
+		b.rate = min(b.base, b.rate+b.base*recoverFactor)
+		b.successes = 0
+	}
+}
+
+// currentRate returns the bucket's adapted rate in requests per second.
+func (b *tokenBucket) currentRate() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rate
+}
+
+// parseRetryAfter interprets a Retry-After header given as delay seconds or an HTTP date.
+// It returns 0 if the header is absent, malformed, or in the past.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// waitForSlot paces an outgoing request to endpoint, if rate limiting is enabled.
+func (c *Client) waitForSlot(ctx context.Context, endpoint string) error {
+	if c.limiter == nil {
+		return nil
+	}
+	return c.limiter.bucket(c.config.Endpoints.Name(endpoint)).wait(ctx)
+}
+
+// observeRateLimit feeds a response status back into the endpoint's bucket.
+func (c *Client) observeRateLimit(endpoint string, statusCode int, retryAfter time.Duration) {
+	if c.limiter == nil {
+		return
+	}
+	b := c.limiter.bucket(c.config.Endpoints.Name(endpoint))
+	if statusCode == http.StatusTooManyRequests {
+		b.onRateLimited(retryAfter)
+	} else {
+		b.onSuccess()
+	}
+}
@@ -0,0 +1,158 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Pacing(t *testing.T) {
+	b := newTokenBucket(RateLimit{RequestsPerSecond: 50, Burst: 2})
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 6 {
+		if err := b.wait(ctx); err != nil {
+			t.Fatalf("wait failed: %v", err)
+		}
+	}
+	// 2 burst tokens, then 4 more at 50/s = ~80ms
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("expected pacing of at least 60ms, took %v", elapsed)
+	}
+}
+
+func TestTokenBucket_ContextCancellation(t *testing.T) {
+	b := newTokenBucket(RateLimit{RequestsPerSecond: 0.5, Burst: 1})
+	if err := b.wait(context.Background()); err != nil {
+		t.Fatalf("first wait failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := b.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestTokenBucket_AdaptiveBackoff(t *testing.T) {
+	b := newTokenBucket(RateLimit{RequestsPerSecond: 10, Burst: 1, MinRequestsPerSecond: 2})
+
+	b.onRateLimited(0)
+	if got := b.currentRate(); got != 10 {
+		t.Errorf("rate after one 429 = %v, want 10", got)
+	}
+
+	b.onRateLimited(0)
+	if got := b.currentRate(); got != 5 {
+		t.Errorf("rate after two 429s = %v, want 5", got)
+	}
+
+	for range 5 {
+		b.onRateLimited(0)
+	}
+	if got := b.currentRate(); got != 2 {
+		t.Errorf("rate should not drop below floor, got %v", got)
+	}
+
+	// Recovery is gradual: one step per recoverAfter successes
+	for range recoverAfter {
+		b.onSuccess()
+	}
+	if got := b.currentRate(); got != 3 {
+		t.Errorf("rate after recovery step = %v, want 3", got)
+	}
+	for range recoverAfter * 20 {
+		b.onSuccess()
+	}
+	if got := b.currentRate(); got != 10 {
+		t.Errorf("rate should recover to base, got %v", got)
+	}
+}
+
+func TestTokenBucket_RetryAfterPause(t *testing.T) {
+	b := newTokenBucket(RateLimit{RequestsPerSecond: 100, Burst: 10})
+	b.onRateLimited(50 * time.Millisecond)
+
+	start := time.Now()
+	if err := b.wait(context.Background()); err != nil {
+		t.Fatalf("wait failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected wait to honor Retry-After pause, took %v", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{"soon", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.header, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestClient_RateLimitEndpointOverride(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/lives-market":
+			json.NewEncoder(w).Encode([]LiveMarketEntry{})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+		RateLimit: &RateLimit{
+			RequestsPerSecond: 1000,
+			Burst:             100,
+			Endpoints: map[string]RateLimit{
+				"LiveMarket": {RequestsPerSecond: 20, Burst: 1},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	start := time.Now()
+	for range 3 {
+		if _, err := client.LiveMarket(ctx); err != nil {
+			t.Fatalf("LiveMarket failed: %v", err)
+		}
+	}
+	// Burst of 1 at 20/s: two waits of ~50ms each
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected LiveMarket override to pace requests, took %v", elapsed)
+	}
+}
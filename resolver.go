@@ -0,0 +1,351 @@
+package nepse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultSymbolRefreshInterval is how often the symbol index is rebuilt in the background.
+const DefaultSymbolRefreshInterval = 6 * time.Hour
+
+// missRefreshAfter is the minimum index age before a lookup miss triggers a
+// refresh, so newly listed securities resolve without waiting for the schedule.
+const missRefreshAfter = time.Minute
+
+// symbolRefreshTimeout bounds an index rebuild. The rebuild is shared by every
+// caller waiting on it, so it runs detached from their contexts.
+const symbolRefreshTimeout = 2 * time.Minute
+
+// SecurityInfo is a security list entry enriched with company metadata.
+type SecurityInfo struct {
+	Security
+	CompanyName    string
+	SectorName     string
+	InstrumentType string
+	ISIN           string // Populated once the security's detail has been fetched
+}
+
+// SymbolResolver maintains indexed lookups over the exchange's securities.
+// It is owned by [Client] and accessed via [Client.Symbols]; the index is loaded
+// lazily on first use and rebuilt periodically in the background.
+//
+// ISIN is not part of NEPSE's list endpoints, so [SymbolResolver.ResolveISIN]
+// only knows securities whose [Client.SecurityDetail] has been fetched.
+type SymbolResolver struct {
+	client   *Client
+	interval time.Duration
+
+	mu       sync.RWMutex
+	bySymbol map[string]*SecurityInfo
+	byID     map[int32]*SecurityInfo
+	byISIN   map[string]*SecurityInfo
+	symbols  []string // sorted, for prefix search
+	loadedAt time.Time
+
+	sf     singleflight.Group
+	ctx    context.Context // Cancelled by close; bounds detached rebuilds
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newSymbolResolver(c *Client, interval time.Duration) *SymbolResolver {
+	r := &SymbolResolver{
+		client:   c,
+		interval: interval,
+		bySymbol: make(map[string]*SecurityInfo),
+		byID:     make(map[int32]*SecurityInfo),
+		byISIN:   make(map[string]*SecurityInfo),
+		done:     make(chan struct{}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.ctx, r.cancel = ctx, cancel
+	if interval > 0 {
+		go r.refreshLoop(ctx)
+	} else {
+		close(r.done)
+	}
+	return r
+}
+
+// Resolve returns the security with the given ticker symbol (case-insensitive).
+func (r *SymbolResolver) Resolve(ctx context.Context, symbol string) (*SecurityInfo, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if symbol == "" {
+		return nil, NewInvalidClientRequestError("symbol cannot be empty")
+	}
+	return r.lookup(ctx, func() *SecurityInfo { return r.bySymbol[symbol] },
+		func() error { return NewNotFoundError("security with symbol " + symbol) })
+}
+
+// ResolveID returns the security with the given ID.
+func (r *SymbolResolver) ResolveID(ctx context.Context, id int32) (*SecurityInfo, error) {
+	if id <= 0 {
+		return nil, NewInvalidClientRequestError("security ID must be positive")
+	}
+	return r.lookup(ctx, func() *SecurityInfo { return r.byID[id] },
+		func() error { return NewNotFoundError(fmt.Sprintf("security with ID %d", id)) })
+}
+
+// ResolveISIN returns the security with the given ISIN, if it is known.
+func (r *SymbolResolver) ResolveISIN(ctx context.Context, isin string) (*SecurityInfo, error) {
+	isin = strings.ToUpper(strings.TrimSpace(isin))
+	if isin == "" {
+		return nil, NewInvalidClientRequestError("ISIN cannot be empty")
+	}
+	return r.lookup(ctx, func() *SecurityInfo { return r.byISIN[isin] },
+		func() error { return NewNotFoundError("security with ISIN " + isin) })
+}
+
+// lookup runs find against the index, loading it first if needed and refreshing
+// once on a miss if the index is older than missRefreshAfter.
+func (r *SymbolResolver) lookup(ctx context.Context, find func() *SecurityInfo, notFound func() error) (*SecurityInfo, error) {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	if info, ok := r.find(find); ok {
+		return info, nil
+	}
+
+	if r.age() < missRefreshAfter {
+		return nil, notFound()
+	}
+	if err := r.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	if info, ok := r.find(find); ok {
+		return info, nil
+	}
+	return nil, notFound()
+}
+
+func (r *SymbolResolver) find(find func() *SecurityInfo) (*SecurityInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if info := find(); info != nil {
+		cp := *info
+		return &cp, true
+	}
+	return nil, false
+}
+
+// Search returns up to limit securities matching query, best matches first.
+// Exact symbols rank highest, then symbol prefixes, then security or company
+// names containing query, then symbols within a small edit distance.
+// A non-positive limit returns all matches.
+func (r *SymbolResolver) Search(ctx context.Context, query string, limit int) ([]SecurityInfo, error) {
+	query = strings.ToUpper(strings.TrimSpace(query))
+	if query == "" {
+		return nil, NewInvalidClientRequestError("search query cannot be empty")
+	}
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		info *SecurityInfo
+		rank int
+	}
+
+	r.mu.RLock()
+	var matches []match
+	for _, symbol := range r.symbols {
+		info := r.bySymbol[symbol]
+		switch {
+		case symbol == query:
+			matches = append(matches, match{info, 0})
+		case strings.HasPrefix(symbol, query):
+			matches = append(matches, match{info, 1})
+		case strings.Contains(strings.ToUpper(info.SecurityName), query),
+			strings.Contains(strings.ToUpper(info.CompanyName), query):
+			matches = append(matches, match{info, 2})
+		default:
+			if d := levenshtein(symbol, query); d <= 2 {
+				matches = append(matches, match{info, 2 + d})
+			}
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].rank < matches[j].rank
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	results := make([]SecurityInfo, len(matches))
+	for i, m := range matches {
+		results[i] = *m.info
+	}
+	return results, nil
+}
+
+// Refresh rebuilds the index from the security and company lists.
+// Concurrent calls share a single fetch. Cancelling ctx stops this call from
+// waiting but not the fetch, which other callers may still be waiting on.
+func (r *SymbolResolver) Refresh(ctx context.Context) error {
+	ch := r.sf.DoChan("refresh", func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), symbolRefreshTimeout)
+		defer cancel()
+		stop := context.AfterFunc(r.ctx, cancel)
+		defer stop()
+		return nil, r.refresh(fetchCtx)
+	})
+	select {
+	case res := <-ch:
+		return res.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *SymbolResolver) refresh(ctx context.Context) error {
+	// Bypass the response cache so a refresh always sees current listings.
+	ctx = withoutCache(ctx)
+
+	var securities []Security
+	var companies []Company
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		securities, err = r.client.Securities(gctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		companies, err = r.client.Companies(gctx)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	companyBySymbol := make(map[string]*Company, len(companies))
+	for i := range companies {
+		companyBySymbol[strings.ToUpper(companies[i].Symbol)] = &companies[i]
+	}
+
+	bySymbol := make(map[string]*SecurityInfo, len(securities))
+	byID := make(map[int32]*SecurityInfo, len(securities))
+	symbols := make([]string, 0, len(securities))
+	for _, s := range securities {
+		symbol := strings.ToUpper(s.Symbol)
+		info := &SecurityInfo{Security: s}
+		if company, ok := companyBySymbol[symbol]; ok {
+			info.CompanyName = company.CompanyName
+			info.SectorName = company.SectorName
+			info.InstrumentType = company.InstrumentType
+		}
+		bySymbol[symbol] = info
+		byID[s.ID] = info
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Carry over ISINs learned from security detail lookups.
+	byISIN := make(map[string]*SecurityInfo, len(r.byISIN))
+	for isin, old := range r.byISIN {
+		if info, ok := byID[old.ID]; ok {
+			info.ISIN = isin
+			byISIN[isin] = info
+		}
+	}
+
+	r.bySymbol = bySymbol
+	r.byID = byID
+	r.byISIN = byISIN
+	r.symbols = symbols
+	r.loadedAt = time.Now()
+	return nil
+}
+
+// ensureLoaded loads the index on first use.
+func (r *SymbolResolver) ensureLoaded(ctx context.Context) error {
+	if r.loaded() {
+		return nil
+	}
+	return r.Refresh(ctx)
+}
+
+func (r *SymbolResolver) loaded() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !r.loadedAt.IsZero()
+}
+
+func (r *SymbolResolver) age() time.Duration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return time.Since(r.loadedAt)
+}
+
+// recordISIN indexes a security's ISIN once it has been learned from a detail response.
+func (r *SymbolResolver) recordISIN(id int32, isin string) {
+	isin = strings.ToUpper(strings.TrimSpace(isin))
+	if isin == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if info, ok := r.byID[id]; ok {
+		info.ISIN = isin
+		r.byISIN[isin] = info
+	}
+}
+
+// refreshLoop rebuilds an already loaded index every interval until ctx is cancelled.
+func (r *SymbolResolver) refreshLoop(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if r.loaded() {
+				// Errors are ignored; the previous index stays in use until the next tick.
+				_ = r.Refresh(ctx)
+			}
+		}
+	}
+}
+
+// close stops the background refresh and waits for it to exit.
+func (r *SymbolResolver) close() {
+	r.cancel()
+	<-r.done
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,212 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsbohara/go-nepse/internal/auth"
+)
+
+func resolverHandler(securityCalls *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/security":
+			securityCalls.Add(1)
+			json.NewEncoder(w).Encode([]Security{
+				{ID: 131, Symbol: "NABIL", SecurityName: "Nabil Bank Limited", ActiveStatus: "A"},
+				{ID: 132, Symbol: "NABBC", SecurityName: "Narayani Development Bank Limited", ActiveStatus: "A"},
+				{ID: 2790, Symbol: "NICA", SecurityName: "NIC Asia Bank Ltd.", ActiveStatus: "A"},
+			})
+		case "/api/nots/company/list":
+			json.NewEncoder(w).Encode([]Company{
+				{ID: 131, Symbol: "NABIL", CompanyName: "Nabil Bank Limited", SectorName: SectorBanking},
+				{ID: 2790, Symbol: "NICA", CompanyName: "NIC Asia Bank Ltd.", SectorName: SectorBanking},
+			})
+		case "/api/nots/security/profile/131":
+			json.NewEncoder(w).Encode(CompanyProfile{CompanyName: "Nabil Bank Limited"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestSymbolResolver_Resolve(t *testing.T) {
+	var securityCalls atomic.Int32
+	client := newTestClient(t, resolverHandler(&securityCalls))
+	ctx := context.Background()
+
+	info, err := client.Symbols().Resolve(ctx, " nabil ")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if info.ID != 131 || info.SectorName != SectorBanking {
+		t.Errorf("unexpected info: %+v", info)
+	}
+
+	info, err = client.Symbols().ResolveID(ctx, 2790)
+	if err != nil {
+		t.Fatalf("ResolveID failed: %v", err)
+	}
+	if info.Symbol != "NICA" {
+		t.Errorf("ResolveID(2790).Symbol = %q, want NICA", info.Symbol)
+	}
+
+	_, err = client.Symbols().Resolve(ctx, "UNKNOWN")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	_, err = client.Symbols().ResolveISIN(ctx, "NPE014A00007")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown ISIN, got %v", err)
+	}
+	_, err = client.Symbols().Resolve(ctx, "")
+	if !errors.Is(err, ErrInvalidClientRequest) {
+		t.Errorf("expected ErrInvalidClientRequest, got %v", err)
+	}
+
+	// All lookups should be served from a single index build
+	if securityCalls.Load() != 1 {
+		t.Errorf("expected 1 security list call, got %d", securityCalls.Load())
+	}
+}
+
+func TestSymbolResolver_Search(t *testing.T) {
+	var securityCalls atomic.Int32
+	client := newTestClient(t, resolverHandler(&securityCalls))
+
+	results, err := client.Symbols().Search(context.Background(), "nab", 0)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 || results[0].Symbol != "NABBC" || results[1].Symbol != "NABIL" {
+		t.Errorf("prefix search = %+v, want NABBC, NABIL", results)
+	}
+
+	results, err = client.Symbols().Search(context.Background(), "NABL", 1)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Symbol != "NABIL" {
+		t.Errorf("fuzzy search = %+v, want NABIL", results)
+	}
+}
+
+func TestClient_BySymbolUsesResolver(t *testing.T) {
+	var securityCalls atomic.Int32
+	client := newTestClient(t, resolverHandler(&securityCalls))
+	ctx := context.Background()
+
+	for range 3 {
+		profile, err := client.CompanyProfileBySymbol(ctx, "NABIL")
+		if err != nil {
+			t.Fatalf("CompanyProfileBySymbol failed: %v", err)
+		}
+		if profile.CompanyName != "Nabil Bank Limited" {
+			t.Errorf("unexpected profile: %+v", profile)
+		}
+	}
+
+	if securityCalls.Load() != 1 {
+		t.Errorf("expected 1 security list call, got %d", securityCalls.Load())
+	}
+}
+
+func TestSymbolResolver_RefreshSurvivesCancelledCaller(t *testing.T) {
+	release := make(chan struct{})
+	var securityCalls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/security":
+			securityCalls.Add(1)
+			<-release
+			json.NewEncoder(w).Encode([]Security{{ID: 131, Symbol: "NABIL", ActiveStatus: "A"}})
+		case "/api/nots/company/list":
+			json.NewEncoder(w).Encode([]Company{})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	client := newTestClient(t, handler)
+
+	// The first caller starts the shared fetch and gives up while it is in flight
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() { first <- client.Symbols().Refresh(ctx) }()
+	for securityCalls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	second := make(chan error, 1)
+	go func() { second <- client.Symbols().Refresh(context.Background()) }()
+	time.Sleep(10 * time.Millisecond) // let the second caller join the fetch
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller got %v, want context.Canceled", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("waiting caller failed with %v, want the shared fetch to finish", err)
+	}
+	if _, err := client.Symbols().Resolve(context.Background(), "NABIL"); err != nil {
+		t.Errorf("Resolve after refresh failed: %v", err)
+	}
+	if n := securityCalls.Load(); n != 1 {
+		t.Errorf("security list fetched %d times, want 1", n)
+	}
+}
+
+func TestNewClient_AuthFailureLeavesNoGoroutine(t *testing.T) {
+	orig := newAuthManager
+	newAuthManager = func(auth.NepseHTTP, ...auth.Option) (*auth.Manager, error) {
+		return nil, errors.New("wasm unavailable")
+	}
+	defer func() { newAuthManager = orig }()
+
+	before := runtime.NumGoroutine()
+	for range 10 {
+		client, err := NewClient(&Options{
+			BaseURL:               "http://127.0.0.1",
+			Config:                &Config{BaseURL: "http://127.0.0.1", Endpoints: DefaultEndpoints()},
+			SymbolRefreshInterval: time.Hour,
+		})
+		if client != nil || !errors.Is(err, ErrInternal) {
+			t.Fatalf("NewClient = %v, %v; want nil and an internal error", client, err)
+		}
+	}
+
+	// Give any leaked refresh loop a chance to show up
+	time.Sleep(10 * time.Millisecond)
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines grew from %d to %d after failed NewClient calls", before, after)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"NABIL", "NABIL", 0},
+		{"NABIL", "NABLI", 2},
+		{"NICA", "NIC", 1},
+		{"", "ABC", 3},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
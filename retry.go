@@ -0,0 +1,143 @@
+package nepse
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxRetryDelay caps retry delays for policies that leave Cap unset.
+const DefaultMaxRetryDelay = 30 * time.Second
+
+// RetryAttempt describes a failed request attempt passed to a [RetryPolicy].
+type RetryAttempt struct {
+	Method   string         // HTTP method of the request
+	Endpoint string         // Path and query relative to BaseURL
+	Attempt  int            // 1-based number of the attempt that failed
+	Response *http.Response // Nil on transport errors; the body has been read into Err
+	Err      *NepseError    // Classified failure; Err.Type tells network, rate-limit and server errors apart
+
+	// PrevDelay is the delay that preceded the failed attempt; zero for the first attempt.
+	PrevDelay time.Duration
+}
+
+// RetryPolicy decides whether a failed attempt is retried and how long to wait first.
+//
+// The client consults the policy for transport errors and HTTP error responses,
+// but never for 401s, which are answered by refreshing the token and resending
+// once. Options.MaxRetries bounds the number of retries regardless of the
+// policy, and a Retry-After header longer than the returned delay is honored.
+type RetryPolicy interface {
+	Retry(a RetryAttempt) (delay time.Duration, retry bool)
+}
+
+// RetryPolicyFunc adapts a function to a [RetryPolicy].
+type RetryPolicyFunc func(a RetryAttempt) (time.Duration, bool)
+
+// Retry implements [RetryPolicy].
+func (f RetryPolicyFunc) Retry(a RetryAttempt) (time.Duration, bool) {
+	return f(a)
+}
+
+// ExponentialBackoff waits Base * 2^(attempt-1), capped at Cap.
+// This is the default policy, built from Options.RetryDelay.
+type ExponentialBackoff struct {
+	Base time.Duration // Delay before the first retry
+	Cap  time.Duration // Maximum delay; zero uses DefaultMaxRetryDelay
+}
+
+// Retry implements [RetryPolicy].
+func (p ExponentialBackoff) Retry(a RetryAttempt) (time.Duration, bool) {
+	if !ShouldRetry(a) {
+		return 0, false
+	}
+	return backoff(p.Base, p.Cap, a.Attempt), true
+}
+
+// FullJitter waits a random duration between zero and the exponential backoff
+// delay, which spreads out clients that failed at the same time.
+type FullJitter struct {
+	Base time.Duration // Backoff delay before the first retry
+	Cap  time.Duration // Maximum delay; zero uses DefaultMaxRetryDelay
+}
+
+// Retry implements [RetryPolicy].
+func (p FullJitter) Retry(a RetryAttempt) (time.Duration, bool) {
+	if !ShouldRetry(a) {
+		return 0, false
+	}
+	return randDuration(0, backoff(p.Base, p.Cap, a.Attempt)), true
+}
+
+// DecorrelatedJitter waits a random duration between Base and three times the
+// previous delay, capped at Cap. Delays grow like exponential backoff but are
+// not synchronized across clients.
+type DecorrelatedJitter struct {
+	Base time.Duration // Minimum delay
+	Cap  time.Duration // Maximum delay; zero uses DefaultMaxRetryDelay
+}
+
+// Retry implements [RetryPolicy].
+func (p DecorrelatedJitter) Retry(a RetryAttempt) (time.Duration, bool) {
+	if !ShouldRetry(a) {
+		return 0, false
+	}
+	upper := min(retryCap(p.Cap), max(p.Base, 3*a.PrevDelay))
+	return randDuration(min(p.Base, upper), upper), true
+}
+
+// ShouldRetry is the retry decision used by the built-in policies. It retries
+// errors whose [NepseError.IsRetryable] reports true. Non-idempotent requests
+// (POST) are only retried on responses showing the request never reached
+// NEPSE's application servers: 429, 502, 503 and 504.
+func ShouldRetry(a RetryAttempt) bool {
+	if a.Err == nil || !a.Err.IsRetryable() {
+		return false
+	}
+	if isIdempotent(a.Method) {
+		return true
+	}
+	if a.Response == nil {
+		return false
+	}
+	switch a.Response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func retryCap(limit time.Duration) time.Duration {
+	if limit <= 0 {
+		return DefaultMaxRetryDelay
+	}
+	return limit
+}
+
+// backoff returns base * 2^(attempt-1) capped at limit, without overflowing.
+func backoff(base, limit time.Duration, attempt int) time.Duration {
+	limit = retryCap(limit)
+	d := base
+	for i := 1; i < attempt && d < limit; i++ {
+		d *= 2
+	}
+	return min(d, limit)
+}
+
+// randDuration returns a random duration in [lo, hi].
+func randDuration(lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + rand.N(hi-lo+1)
+}
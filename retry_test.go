@@ -0,0 +1,194 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	serverErr := func(status int) RetryAttempt {
+		return RetryAttempt{
+			Response: &http.Response{StatusCode: status},
+			Err:      MapHTTPStatusToError(status, http.StatusText(status)),
+		}
+	}
+	networkErr := RetryAttempt{Err: NewNetworkError(errors.New("connection reset"))}
+
+	tests := []struct {
+		name    string
+		method  string
+		attempt RetryAttempt
+		want    bool
+	}{
+		{"GET 500", http.MethodGet, serverErr(http.StatusInternalServerError), true},
+		{"GET 429", http.MethodGet, serverErr(http.StatusTooManyRequests), true},
+		{"GET network", http.MethodGet, networkErr, true},
+		{"GET 404", http.MethodGet, serverErr(http.StatusNotFound), false},
+		{"GET 403", http.MethodGet, serverErr(http.StatusForbidden), false},
+		{"POST 503", http.MethodPost, serverErr(http.StatusServiceUnavailable), true},
+		{"POST 429", http.MethodPost, serverErr(http.StatusTooManyRequests), true},
+		{"POST 500", http.MethodPost, serverErr(http.StatusInternalServerError), false},
+		{"POST network", http.MethodPost, networkErr, false},
+		{"no error", http.MethodGet, RetryAttempt{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.attempt
+			a.Method = tt.method
+			if got := ShouldRetry(a); got != tt.want {
+				t.Errorf("ShouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicies_Delays(t *testing.T) {
+	failed := func(attempt int, prev time.Duration) RetryAttempt {
+		return RetryAttempt{
+			Method:    http.MethodGet,
+			Attempt:   attempt,
+			Err:       NewInvalidServerResponseError("service unavailable"),
+			PrevDelay: prev,
+		}
+	}
+	base, limit := 100*time.Millisecond, time.Second
+
+	exp := ExponentialBackoff{Base: base, Cap: limit}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second, 200: time.Second} {
+		if got, ok := exp.Retry(failed(attempt, 0)); !ok || got != want {
+			t.Errorf("ExponentialBackoff attempt %d = %v, %v; want %v", attempt, got, ok, want)
+		}
+	}
+
+	full := FullJitter{Base: base, Cap: limit}
+	for range 100 {
+		if got, ok := full.Retry(failed(3, 0)); !ok || got < 0 || got > 400*time.Millisecond {
+			t.Fatalf("FullJitter attempt 3 = %v, %v; want within [0, 400ms]", got, ok)
+		}
+	}
+
+	decorrelated := DecorrelatedJitter{Base: base, Cap: limit}
+	prev := time.Duration(0)
+	for attempt := 1; attempt <= 20; attempt++ {
+		got, ok := decorrelated.Retry(failed(attempt, prev))
+		if !ok || got < base || got > limit || got > max(base, 3*prev) {
+			t.Fatalf("DecorrelatedJitter attempt %d (prev %v) = %v, %v", attempt, prev, got, ok)
+		}
+		prev = got
+	}
+
+	if _, ok := exp.Retry(RetryAttempt{Method: http.MethodGet, Err: NewNotFoundError("")}); ok {
+		t.Error("policies should not retry non-retryable errors")
+	}
+}
+
+func TestClient_RetryPolicy(t *testing.T) {
+	var calls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/nepse-data/market-open":
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	var seen []RetryAttempt
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  5,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+		RetryPolicy: RetryPolicyFunc(func(a RetryAttempt) (time.Duration, bool) {
+			seen = append(seen, a)
+			return time.Millisecond, a.Attempt < 3
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.MarketStatus(context.Background())
+	var nepseErr *NepseError
+	if !errors.As(err, &nepseErr) {
+		t.Fatalf("expected *NepseError, got %v", err)
+	}
+	if nepseErr.Attempts() != 3 || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("error should report 3 attempts, got %d (%v)", nepseErr.Attempts(), err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", calls.Load())
+	}
+
+	if len(seen) != 3 {
+		t.Fatalf("policy consulted %d times, want 3", len(seen))
+	}
+	for i, a := range seen {
+		if a.Attempt != i+1 || a.Method != http.MethodGet || a.Endpoint != "/api/nots/nepse-data/market-open" {
+			t.Errorf("attempt %d: unexpected %+v", i+1, a)
+		}
+		if a.Response == nil || a.Response.StatusCode != http.StatusInternalServerError || a.Err.Type != ErrorTypeInvalidServerResponse {
+			t.Errorf("attempt %d: expected classified 500 response", i+1)
+		}
+	}
+	if seen[0].PrevDelay != 0 || seen[1].PrevDelay != time.Millisecond {
+		t.Errorf("PrevDelay = %v, %v; want 0, 1ms", seen[0].PrevDelay, seen[1].PrevDelay)
+	}
+}
+
+func TestClient_PostNotRetriedOnServerError(t *testing.T) {
+	var posts atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/graph/index/58":
+			posts.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  3,
+		RetryDelay:  time.Millisecond,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.DebugRawPostRequest(context.Background(), "/api/nots/graph/index/58", graphPostPayload{ID: 1})
+	if !errors.Is(err, ErrInvalidServerResponse) {
+		t.Fatalf("expected server error, got %v", err)
+	}
+	if posts.Load() != 1 {
+		t.Errorf("POST should not be retried on 500, got %d requests", posts.Load())
+	}
+}
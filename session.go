@@ -0,0 +1,239 @@
+package nepse
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSessionInterval is how often a [SessionWatcher] checks the market status.
+const DefaultSessionInterval = 30 * time.Second
+
+// NEPSE's trading schedule in Nepal time, as offsets from midnight.
+const (
+	PreOpenStart = 10*time.Hour + 30*time.Minute
+	TradingStart = 11 * time.Hour
+	TradingEnd   = 15 * time.Hour
+)
+
+// MarketSession is the trading state of the exchange.
+type MarketSession int
+
+const (
+	SessionUnknown MarketSession = iota // Status not yet known or unrecognized
+	SessionPreOpen                      // Pre-open order collection before continuous trading
+	SessionOpen                         // Continuous trading
+	SessionClosed                       // Outside trading hours or on a weekend
+	SessionHoliday                      // Closed during scheduled trading hours
+)
+
+// String returns the session name.
+func (s MarketSession) String() string {
+	switch s {
+	case SessionPreOpen:
+		return "pre_open"
+	case SessionOpen:
+		return "open"
+	case SessionClosed:
+		return "closed"
+	case SessionHoliday:
+		return "holiday"
+	default:
+		return "unknown"
+	}
+}
+
+// Session returns the session reported by NEPSE. Closed markets are reported
+// as [SessionClosed]; use [SessionWatcher] to tell holidays apart.
+func (m *MarketStatus) Session() MarketSession {
+	switch strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToUpper(m.IsOpen)) {
+	case "OPEN":
+		return SessionOpen
+	case "PREOPEN":
+		return SessionPreOpen
+	case "CLOSE", "CLOSED":
+		return SessionClosed
+	default:
+		return SessionUnknown
+	}
+}
+
+// IsTradingDay reports whether t falls on Sunday through Thursday in Nepal time.
+func IsTradingDay(t time.Time) bool {
+	wd := t.In(NPT).Weekday()
+	return wd != time.Friday && wd != time.Saturday
+}
+
+// ScheduledSession returns the session NEPSE's regular schedule has at t,
+// ignoring holidays.
+func ScheduledSession(t time.Time) MarketSession {
+	t = t.In(NPT)
+	if !IsTradingDay(t) {
+		return SessionClosed
+	}
+	switch offset := sinceMidnight(t); {
+	case offset >= PreOpenStart && offset < TradingStart:
+		return SessionPreOpen
+	case offset >= TradingStart && offset < TradingEnd:
+		return SessionOpen
+	default:
+		return SessionClosed
+	}
+}
+
+// NextOpen returns the next scheduled start of continuous trading after t,
+// ignoring holidays. During trading hours it returns the next day's open.
+func NextOpen(t time.Time) time.Time {
+	t = t.In(NPT)
+	day := midnight(t)
+	if sinceMidnight(t) >= TradingStart {
+		day = day.AddDate(0, 0, 1)
+	}
+	for !IsTradingDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day.Add(TradingStart)
+}
+
+// TimeUntilClose returns how long continuous trading lasts after t, or zero
+// if the schedule has the market closed at t.
+func TimeUntilClose(t time.Time) time.Duration {
+	if ScheduledSession(t) != SessionOpen {
+		return 0
+	}
+	t = t.In(NPT)
+	return midnight(t).Add(TradingEnd).Sub(t)
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return t.Sub(midnight(t))
+}
+
+// SessionChange is a transition between market sessions.
+type SessionChange struct {
+	From   MarketSession
+	To     MarketSession
+	At     time.Time // MarketStatus.AsOf, or when the change was seen if unset
+	Status MarketStatus
+}
+
+// SessionWatcherOptions configures a [SessionWatcher].
+type SessionWatcherOptions struct {
+	// Interval between market status checks. Zero uses DefaultSessionInterval.
+	Interval time.Duration
+}
+
+// SessionWatcher polls [Client.MarketStatus] and reports session transitions.
+// A market reported closed while the schedule has it open or in pre-open is
+// reported as [SessionHoliday].
+type SessionWatcher struct {
+	client   *Client
+	interval time.Duration
+
+	mu      sync.Mutex
+	session MarketSession
+	err     error
+}
+
+// NewSessionWatcher returns a session watcher over client. opts may be nil.
+func NewSessionWatcher(client *Client, opts *SessionWatcherOptions) *SessionWatcher {
+	w := &SessionWatcher{client: client, interval: DefaultSessionInterval}
+	if opts != nil && opts.Interval > 0 {
+		w.interval = opts.Interval
+	}
+	return w
+}
+
+// Session returns the most recently observed session.
+func (w *SessionWatcher) Session() MarketSession {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.session
+}
+
+// Run checks the status every interval and calls fn for each transition,
+// starting with the change from [SessionUnknown] to the current session.
+// Failed checks are logged and retried. Run returns ctx's error when ctx is done.
+func (w *SessionWatcher) Run(ctx context.Context, fn func(SessionChange)) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		status, err := w.client.MarketStatus(withoutCache(ctx))
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			w.client.logger.Warn("session watcher status check failed", slog.Any("error", err))
+		default:
+			if change, ok := w.observe(status, w.client.clock.Now()); ok {
+				fn(change)
+			}
+		}
+		timer.Reset(w.interval)
+	}
+}
+
+// Changes runs the watcher in a goroutine and sends transitions on the returned
+// channel, which is closed when ctx is done. [SessionWatcher.Err] reports why.
+func (w *SessionWatcher) Changes(ctx context.Context) <-chan SessionChange {
+	ch := make(chan SessionChange)
+	go func() {
+		defer close(ch)
+		err := w.Run(ctx, func(change SessionChange) {
+			select {
+			case ch <- change:
+			case <-ctx.Done():
+			}
+		})
+		w.mu.Lock()
+		w.err = err
+		w.mu.Unlock()
+	}()
+	return ch
+}
+
+// Err returns the error that closed the channel from [SessionWatcher.Changes].
+func (w *SessionWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// observe records status and returns the transition it causes, if any.
+func (w *SessionWatcher) observe(status *MarketStatus, now time.Time) (SessionChange, bool) {
+	at := status.AsOf.Time
+	if at.IsZero() {
+		at = now
+	}
+
+	// AsOf keeps the last close time while the market stays shut, so holidays
+	// are judged against the current time.
+	session := status.Session()
+	if session == SessionClosed {
+		if scheduled := ScheduledSession(now); scheduled == SessionOpen || scheduled == SessionPreOpen {
+			session = SessionHoliday
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if session == w.session {
+		return SessionChange{}, false
+	}
+	change := SessionChange{From: w.session, To: session, At: at, Status: *status}
+	w.session = session
+	return change, true
+}
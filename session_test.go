@@ -0,0 +1,220 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsbohara/go-nepse/nepsetest"
+)
+
+func nptTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	ts, err := time.ParseInLocation("2006-01-02 15:04", value, NPT)
+	if err != nil {
+		t.Fatalf("bad test time %q: %v", value, err)
+	}
+	return ts
+}
+
+func TestMarketStatus_Session(t *testing.T) {
+	tests := map[string]MarketSession{
+		"OPEN":     SessionOpen,
+		"CLOSE":    SessionClosed,
+		"Closed":   SessionClosed,
+		"PRE-OPEN": SessionPreOpen,
+		"Pre Open": SessionPreOpen,
+		"":         SessionUnknown,
+		"HALT":     SessionUnknown,
+	}
+	for isOpen, want := range tests {
+		status := MarketStatus{IsOpen: isOpen}
+		if got := status.Session(); got != want {
+			t.Errorf("Session(%q) = %s, want %s", isOpen, got, want)
+		}
+	}
+	if (&MarketStatus{IsOpen: "OPEN"}).IsMarketOpen() != true {
+		t.Error("IsMarketOpen should be true for OPEN")
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	// 2026-01-04 is a Sunday
+	tests := []struct {
+		at         string
+		session    MarketSession
+		nextOpen   string
+		untilClose time.Duration
+	}{
+		{"2026-01-04 09:00", SessionClosed, "2026-01-04 11:00", 0},
+		{"2026-01-04 10:40", SessionPreOpen, "2026-01-04 11:00", 0},
+		{"2026-01-04 11:00", SessionOpen, "2026-01-05 11:00", 4 * time.Hour},
+		{"2026-01-04 14:30", SessionOpen, "2026-01-05 11:00", 30 * time.Minute},
+		{"2026-01-04 15:00", SessionClosed, "2026-01-05 11:00", 0},
+		{"2026-01-08 16:00", SessionClosed, "2026-01-11 11:00", 0}, // Thursday evening
+		{"2026-01-09 12:00", SessionClosed, "2026-01-11 11:00", 0}, // Friday
+		{"2026-01-10 12:00", SessionClosed, "2026-01-11 11:00", 0}, // Saturday
+	}
+	for _, tt := range tests {
+		at := nptTime(t, tt.at)
+		if got := ScheduledSession(at); got != tt.session {
+			t.Errorf("ScheduledSession(%s) = %s, want %s", tt.at, got, tt.session)
+		}
+		if got, want := NextOpen(at), nptTime(t, tt.nextOpen); !got.Equal(want) {
+			t.Errorf("NextOpen(%s) = %v, want %v", tt.at, got, want)
+		}
+		if got := TimeUntilClose(at); got != tt.untilClose {
+			t.Errorf("TimeUntilClose(%s) = %v, want %v", tt.at, got, tt.untilClose)
+		}
+	}
+
+	// Times in other zones are converted to Nepal time first
+	if got := ScheduledSession(nptTime(t, "2026-01-04 11:30").UTC()); got != SessionOpen {
+		t.Errorf("ScheduledSession(UTC) = %s, want open", got)
+	}
+}
+
+func TestSessionWatcher_Observe(t *testing.T) {
+	w := NewSessionWatcher(nil, nil)
+	steps := []struct {
+		isOpen, asOf, now string
+		want              MarketSession
+		changed           bool
+	}{
+		{"CLOSE", "2026-01-04T15:00:00", "2026-01-04 16:00", SessionClosed, true},
+		{"CLOSE", "2026-01-04T15:00:00", "2026-01-04 17:00", SessionClosed, false},
+		// Still closed on Monday during trading hours
+		{"CLOSE", "2026-01-04T15:00:00", "2026-01-05 11:30", SessionHoliday, true},
+		{"OPEN", "2026-01-06T11:00:00", "2026-01-06 11:00", SessionOpen, true},
+	}
+	prev := SessionUnknown
+	for i, step := range steps {
+		asOf, err := ParseDateTime(step.asOf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		change, changed := w.observe(&MarketStatus{IsOpen: step.isOpen, AsOf: asOf}, nptTime(t, step.now))
+		if changed != step.changed || w.Session() != step.want {
+			t.Fatalf("step %d: changed=%v session=%s, want changed=%v session=%s", i, changed, w.Session(), step.changed, step.want)
+		}
+		if changed {
+			if change.From != prev || change.To != step.want {
+				t.Errorf("step %d: change %s -> %s, want %s -> %s", i, change.From, change.To, prev, step.want)
+			}
+			if !change.At.Equal(asOf.Time) {
+				t.Errorf("step %d: At = %v, want AsOf %v", i, change.At, asOf)
+			}
+		}
+		prev = w.Session()
+	}
+}
+
+func TestSessionWatcher_Changes(t *testing.T) {
+	var open atomic.Bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/nepse-data/market-open":
+			status := map[string]any{"isOpen": "PRE-OPEN", "asOf": "2026-01-05T10:30:00"}
+			if open.Load() {
+				status = map[string]any{"isOpen": "OPEN", "asOf": "2026-01-05T11:00:00"}
+			}
+			json.NewEncoder(w).Encode(status)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher := NewSessionWatcher(client, &SessionWatcherOptions{Interval: 10 * time.Millisecond})
+	changes := watcher.Changes(ctx)
+
+	first := <-changes
+	if first.From != SessionUnknown || first.To != SessionPreOpen {
+		t.Errorf("first change %s -> %s, want unknown -> pre_open", first.From, first.To)
+	}
+	open.Store(true)
+	second := <-changes
+	if second.From != SessionPreOpen || second.To != SessionOpen || !second.At.Equal(nptTime(t, "2026-01-05 11:00")) {
+		t.Errorf("second change %s -> %s at %v, want pre_open -> open at 11:00", second.From, second.To, second.At)
+	}
+
+	cancel()
+	for range changes {
+	}
+	if watcher.Err() != context.Canceled {
+		t.Errorf("Err() = %v, want context.Canceled", watcher.Err())
+	}
+}
+
+func TestSessionWatcher_HolidayFollowsClock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/nepse-data/market-open":
+			json.NewEncoder(w).Encode(map[string]any{"isOpen": "CLOSE", "asOf": "2026-01-04T15:00:00"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	// Sunday evening: closed as scheduled
+	clock := nepsetest.NewClock(nptTime(t, "2026-01-04 16:00"))
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+		Clock: clock,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := NewSessionWatcher(client, &SessionWatcherOptions{Interval: 10 * time.Millisecond}).Changes(ctx)
+
+	if change := <-changes; change.To != SessionClosed {
+		t.Fatalf("first change to %s, want closed", change.To)
+	}
+	// Still closed across midnight into Monday's trading hours
+	clock.Set(nptTime(t, "2026-01-05 11:30"))
+	if change := <-changes; change.From != SessionClosed || change.To != SessionHoliday {
+		t.Errorf("change %s -> %s, want closed -> holiday", change.From, change.To)
+	}
+	clock.Advance(4 * time.Hour)
+	if change := <-changes; change.From != SessionHoliday || change.To != SessionClosed {
+		t.Errorf("change %s -> %s, want holiday -> closed", change.From, change.To)
+	}
+}
@@ -0,0 +1,252 @@
+package nepse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTailInterval is how often a [FloorSheetTail] polls for new trades.
+const DefaultTailInterval = 15 * time.Second
+
+// CheckpointStore persists the highest ContractID a [FloorSheetTail] has
+// delivered, so a restarted tail resumes where the previous one stopped.
+type CheckpointStore interface {
+	// Load returns the saved ContractID, or 0 if nothing has been saved.
+	Load(ctx context.Context) (int64, error)
+	// Save records contractID as delivered.
+	Save(ctx context.Context, contractID int64) error
+}
+
+// MemoryCheckpoint is a [CheckpointStore] that lives only as long as the process.
+type MemoryCheckpoint struct {
+	mu sync.Mutex
+	id int64
+}
+
+// Load returns the last saved ContractID.
+func (m *MemoryCheckpoint) Load(context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.id, nil
+}
+
+// Save records contractID.
+func (m *MemoryCheckpoint) Save(_ context.Context, contractID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.id = contractID
+	return nil
+}
+
+// FileCheckpoint is a [CheckpointStore] that keeps the ContractID in a text file.
+// Saves replace the file atomically, so a crash never leaves a partial checkpoint.
+type FileCheckpoint struct {
+	Path string
+}
+
+// Load reads the checkpoint file; a missing file means no checkpoint.
+func (f FileCheckpoint) Load(context.Context) (int64, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("nepse: invalid floor sheet checkpoint %s: %w", f.Path, err)
+	}
+	return id, nil
+}
+
+// Save writes contractID to a temporary file and renames it over Path.
+func (f FileCheckpoint) Save(_ context.Context, contractID int64) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(strconv.FormatInt(contractID, 10) + "\n"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// FloorSheetTailOptions configures a [FloorSheetTail].
+type FloorSheetTailOptions struct {
+	// Interval between polls. Zero uses DefaultTailInterval.
+	Interval time.Duration
+	// Checkpoint stores the last delivered ContractID. Nil keeps it in memory,
+	// so a new tail starts with the whole day's floor sheet.
+	Checkpoint CheckpointStore
+}
+
+// FloorSheetTail delivers new floor sheet trades as they appear. Each poll walks
+// the floor sheet newest first and stops at the first ContractID it has already
+// delivered, so only the pages holding new trades are fetched.
+//
+// Trades are delivered in ascending ContractID order. The checkpoint advances
+// after each delivered trade, so a restart never skips trades but may repeat
+// the one being handled when the process stopped.
+type FloorSheetTail struct {
+	client     *Client
+	interval   time.Duration
+	checkpoint CheckpointStore
+
+	mu     sync.Mutex // serializes polls
+	loaded bool
+	last   atomic.Int64
+
+	errMu sync.Mutex
+	err   error
+}
+
+// NewFloorSheetTail returns a tail over client's floor sheet. opts may be nil.
+func NewFloorSheetTail(client *Client, opts *FloorSheetTailOptions) *FloorSheetTail {
+	t := &FloorSheetTail{client: client, interval: DefaultTailInterval, checkpoint: &MemoryCheckpoint{}}
+	if opts != nil {
+		if opts.Interval > 0 {
+			t.interval = opts.Interval
+		}
+		if opts.Checkpoint != nil {
+			t.checkpoint = opts.Checkpoint
+		}
+	}
+	return t
+}
+
+// LastContractID returns the highest ContractID delivered so far.
+func (t *FloorSheetTail) LastContractID() int64 {
+	return t.last.Load()
+}
+
+// Poll fetches trades newer than the checkpoint once and delivers them to fn.
+// It returns the first error from fetching, fn or the checkpoint store; trades
+// delivered before the error remain checkpointed.
+func (t *FloorSheetTail) Poll(ctx context.Context, fn func(FloorSheetEntry) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.loaded {
+		last, err := t.checkpoint.Load(ctx)
+		if err != nil {
+			return err
+		}
+		t.last.Store(last)
+		t.loaded = true
+	}
+
+	last := t.last.Load()
+	// Pages shift while trades are added, so the same trade can show up twice.
+	seen := make(map[int64]bool)
+	var fresh []FloorSheetEntry
+	for entry, err := range t.client.FloorSheetSeq(ctx) {
+		if err != nil {
+			return err
+		}
+		if entry.ContractID <= last {
+			break
+		}
+		if !seen[entry.ContractID] {
+			seen[entry.ContractID] = true
+			fresh = append(fresh, entry)
+		}
+	}
+
+	sort.Slice(fresh, func(i, j int) bool { return fresh[i].ContractID < fresh[j].ContractID })
+	for _, entry := range fresh {
+		if err := fn(entry); err != nil {
+			return err
+		}
+		if err := t.checkpoint.Save(ctx, entry.ContractID); err != nil {
+			return err
+		}
+		t.last.Store(entry.ContractID)
+	}
+	return nil
+}
+
+// Run polls every interval and delivers new trades to fn until ctx is done or
+// fn or the checkpoint store returns an error. Fetch errors are logged and
+// retried on the next poll. Run returns ctx's error on cancellation.
+func (t *FloorSheetTail) Run(ctx context.Context, fn func(FloorSheetEntry) error) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		var fnErr error
+		err := t.Poll(ctx, func(entry FloorSheetEntry) error {
+			fnErr = fn(entry)
+			return fnErr
+		})
+		switch {
+		case fnErr != nil:
+			return fnErr
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case isFetchError(err):
+			t.client.logger.Warn("floor sheet poll failed", slog.Any("error", err))
+		case err != nil:
+			return err
+		}
+		timer.Reset(t.interval)
+	}
+}
+
+// Entries runs the tail in a goroutine and sends new trades on the returned
+// channel, which is closed when ctx is done or the checkpoint store fails.
+// [FloorSheetTail.Err] reports why the channel was closed.
+func (t *FloorSheetTail) Entries(ctx context.Context) <-chan FloorSheetEntry {
+	ch := make(chan FloorSheetEntry)
+	go func() {
+		defer close(ch)
+		err := t.Run(ctx, func(entry FloorSheetEntry) error {
+			select {
+			case ch <- entry:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		t.errMu.Lock()
+		t.err = err
+		t.errMu.Unlock()
+	}()
+	return ch
+}
+
+// Err returns the error that closed the channel from [FloorSheetTail.Entries].
+func (t *FloorSheetTail) Err() error {
+	t.errMu.Lock()
+	defer t.errMu.Unlock()
+	return t.err
+}
+
+// isFetchError reports whether err came from talking to NEPSE rather than from
+// the checkpoint store.
+func isFetchError(err error) bool {
+	var nepseErr *NepseError
+	return errors.As(err, &nepseErr)
+}
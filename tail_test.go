@@ -0,0 +1,178 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tailServer serves the floor sheet for contract IDs 1..n, newest first, in
+// pages of three, recording the pages requested.
+type tailServer struct {
+	mu    sync.Mutex
+	n     int
+	pages []int
+}
+
+func (s *tailServer) add(k int) {
+	s.mu.Lock()
+	s.n += k
+	s.mu.Unlock()
+}
+
+func (s *tailServer) requested() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pages := s.pages
+	s.pages = nil
+	return pages
+}
+
+func tailHandler(s *tailServer) http.Handler {
+	const perPage = 3
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/authenticate/prove" {
+			json.NewEncoder(w).Encode(tokenResponse())
+			return
+		}
+
+		p, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		s.mu.Lock()
+		s.pages = append(s.pages, p)
+		n := s.n
+		s.mu.Unlock()
+
+		totalPages := (n + perPage - 1) / perPage
+		page := PaginatedResponse[FloorSheetEntry]{Content: []FloorSheetEntry{}, TotalPages: int32(totalPages), Last: p >= totalPages-1}
+		for id := n - p*perPage; id > 0 && id > n-(p+1)*perPage; id-- {
+			page.Content = append(page.Content, FloorSheetEntry{ContractID: int64(id)})
+		}
+		json.NewEncoder(w).Encode(FloorSheetResponse{FloorSheets: page})
+	})
+}
+
+func TestFloorSheetTail_PollResumesFromCheckpoint(t *testing.T) {
+	s := &tailServer{n: 5}
+	client := newTestClient(t, tailHandler(s))
+	checkpoint := FileCheckpoint{Path: filepath.Join(t.TempDir(), "floorsheet.checkpoint")}
+	ctx := context.Background()
+
+	poll := func(tail *FloorSheetTail) []int64 {
+		t.Helper()
+		var got []int64
+		if err := tail.Poll(ctx, func(e FloorSheetEntry) error {
+			got = append(got, e.ContractID)
+			return nil
+		}); err != nil {
+			t.Fatalf("Poll failed: %v", err)
+		}
+		return got
+	}
+
+	tail := NewFloorSheetTail(client, &FloorSheetTailOptions{Checkpoint: checkpoint})
+	if got := poll(tail); !slices.Equal(got, []int64{1, 2, 3, 4, 5}) {
+		t.Errorf("first poll delivered %v, want the whole day in ascending order", got)
+	}
+	s.requested()
+
+	s.add(5)
+	if got := poll(tail); !slices.Equal(got, []int64{6, 7, 8, 9, 10}) {
+		t.Errorf("second poll delivered %v, want [6 7 8 9 10]", got)
+	}
+	// Page 1 holds contract 5, which was already delivered
+	if pages := s.requested(); !slices.Equal(pages, []int{0, 1}) {
+		t.Errorf("second poll requested pages %v, want [0 1]", pages)
+	}
+	if data, _ := os.ReadFile(checkpoint.Path); strings.TrimSpace(string(data)) != "10" {
+		t.Errorf("checkpoint file = %q, want 10", data)
+	}
+
+	// A new tail over the same checkpoint only sees trades after a restart
+	s.add(1)
+	restarted := NewFloorSheetTail(client, &FloorSheetTailOptions{Checkpoint: checkpoint})
+	if got := poll(restarted); !slices.Equal(got, []int64{11}) {
+		t.Errorf("restarted tail delivered %v, want [11]", got)
+	}
+	if restarted.LastContractID() != 11 {
+		t.Errorf("LastContractID = %d, want 11", restarted.LastContractID())
+	}
+}
+
+func TestFloorSheetTail_CallbackErrorKeepsCheckpoint(t *testing.T) {
+	s := &tailServer{n: 4}
+	client := newTestClient(t, tailHandler(s))
+	checkpoint := &MemoryCheckpoint{}
+	tail := NewFloorSheetTail(client, &FloorSheetTailOptions{Checkpoint: checkpoint})
+
+	stop := errors.New("stop")
+	err := tail.Run(context.Background(), func(e FloorSheetEntry) error {
+		if e.ContractID == 3 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("Run returned %v, want the callback's error", err)
+	}
+	if id, _ := checkpoint.Load(context.Background()); id != 2 {
+		t.Errorf("checkpoint = %d, want 2 (the last trade handled)", id)
+	}
+}
+
+func TestFloorSheetTail_Entries(t *testing.T) {
+	s := &tailServer{n: 2}
+	client := newTestClient(t, tailHandler(s))
+	tail := NewFloorSheetTail(client, &FloorSheetTailOptions{Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	entries := tail.Entries(ctx)
+
+	var got []int64
+	for e := range entries {
+		got = append(got, e.ContractID)
+		if len(got) == 2 {
+			s.add(2)
+		}
+		if len(got) == 4 {
+			cancel()
+		}
+	}
+	if !slices.Equal(got, []int64{1, 2, 3, 4}) {
+		t.Errorf("received %v, want [1 2 3 4]", got)
+	}
+	if !errors.Is(tail.Err(), context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", tail.Err())
+	}
+}
+
+func TestFloorSheetTail_WithDefaultOptions(t *testing.T) {
+	s := &tailServer{n: 5}
+	client := newTestClient(t, tailHandler(s), withDefaults)
+	tail := NewFloorSheetTail(client, nil)
+
+	// Floor sheet pages are not cached, so new trades show up on the next poll
+	for _, want := range [][]int64{{1, 2, 3, 4, 5}, {6, 7}} {
+		var got []int64
+		if err := tail.Poll(context.Background(), func(e FloorSheetEntry) error {
+			got = append(got, e.ContractID)
+			return nil
+		}); err != nil {
+			t.Fatalf("Poll failed: %v", err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Poll delivered %v, want %v", got, want)
+		}
+		s.add(2)
+	}
+}
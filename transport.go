@@ -5,7 +5,9 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
@@ -36,29 +38,89 @@ func initClient(options *Options) (*Client, error) {
 		httpClient: hc,
 		config:     options.Config,
 		options:    options,
-	}
+		cache:      options.Cache,
+		cacheTTLs:  options.CacheTTLs,
+		limiter:    newRateLimiter(options.RateLimit),
+		logger:     newLogger(options.Logger),
+		metrics:    options.Metrics,
+
+		retryPolicy: options.RetryPolicy,
+		breakers:    newCircuitBreakers(options.CircuitBreaker),
 
-	authManager, err := auth.NewManager(c)
+		clock: options.Clock,
+	}
+	if c.cacheTTLs == nil {
+		c.cacheTTLs = DefaultCacheTTLs()
+	}
+	if c.metrics == nil {
+		c.metrics = NopMetrics{}
+	}
+	if c.clock == nil {
+		c.clock = SystemClock{}
+	}
+	if c.retryPolicy == nil {
+		c.retryPolicy = ExponentialBackoff{Base: options.RetryDelay}
+	}
+	c.roundTrip = chainMiddleware(hc.Do, options.Middleware)
+
+	authManager, err := newAuthManager(c,
+		auth.WithLogger(c.logger),
+		auth.WithClock(c.clock),
+		auth.WithRefreshHook(func(d time.Duration, err error) {
+			c.metrics.TokenRefreshed(d, err == nil)
+		}),
+	)
 	if err != nil {
 		return nil, NewInternalError("failed to create auth manager", err)
 	}
 	c.authManager = authManager
+	// Started last: the resolver's refresh goroutine must not outlive a
+	// client that failed to initialize.
+	c.resolver = newSymbolResolver(c, options.SymbolRefreshInterval)
 
 	return c, nil
 }
 
-// Token implements auth.NepseHTTP interface.
-func (c *Client) Token(ctx context.Context) (*auth.TokenResponse, error) {
-	url := c.config.BaseURL + "/api/authenticate/prove"
+// newAuthManager is replaced in tests to simulate auth initialization failures.
+var newAuthManager = auth.NewManager
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, NewInternalError("failed to create request", err)
-	}
+// tokenPath is the authentication endpoint, which is not part of [Endpoints].
+const tokenPath = "/api/authenticate/prove"
+
+// maxErrorBody limits how much of an error response is read for its message.
+const maxErrorBody = 4 << 10
 
-	c.setCommonHeaders(req)
+// blockedTokenAge is how recently the access token must have been issued for a
+// 403 to be reported as [ErrEndpointBlocked] without refreshing the token first.
+const blockedTokenAge = 5 * time.Second
+
+// requestBuilder creates the request for a single attempt. [Client.doRequest]
+// calls it before every attempt so bodies are never re-sent after being
+// consumed and headers carry the current access token.
+type requestBuilder func(ctx context.Context) (*http.Request, error)
+
+// payloadFunc produces the JSON body of a POST attempt. Payloads derived from
+// the token salts must be recomputed per attempt, since a token refresh
+// between attempts rotates them.
+type payloadFunc func(ctx context.Context) (any, error)
+
+// staticPayload returns a payloadFunc that always produces v.
+func staticPayload(v any) payloadFunc {
+	return func(context.Context) (any, error) { return v, nil }
+}
 
-	resp, err := c.doRequest(req)
+// Token implements auth.NepseHTTP interface.
+func (c *Client) Token(ctx context.Context) (*auth.TokenResponse, error) {
+	url := c.config.BaseURL + tokenPath
+
+	resp, err := c.doRequest(ctx, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, NewInternalError("failed to create request", err)
+		}
+		c.setCommonHeaders(req)
+		return req, nil
+	}, false)
 	if err != nil {
 		return nil, err
 	}
@@ -70,46 +132,225 @@ func (c *Client) Token(ctx context.Context) (*auth.TokenResponse, error) {
 
 	var tokenResp auth.TokenResponse
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		c.logDecodeFailure(tokenPath, err)
 		return nil, NewInternalError("failed to decode token response", err)
 	}
 
 	return &tokenResp, nil
 }
 
-func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
-	var lastErr error
-	maxDelay := 30 * time.Second
-
-	for attempt := 0; attempt <= c.options.MaxRetries; attempt++ {
-		if attempt > 0 {
-			delay := min(c.options.RetryDelay*time.Duration(1<<uint(attempt-1)), maxDelay)
-
-			timer := time.NewTimer(delay)
-			select {
-			case <-req.Context().Done():
-				timer.Stop()
-				return nil, req.Context().Err()
-			case <-timer.C:
+// doRequest sends a request built by newRequest through the middleware chain,
+// retrying failures as the client's [RetryPolicy] decides with a freshly built
+// request each time. Error responses are returned as a *NepseError carrying the
+// attempt count; callers refresh the token on [ErrTokenExpired].
+// tokenRetry is reported to middleware via [RequestInfo]. Requests of a
+// [Client.Diagnose] probe are sent once and bypass the circuit breakers.
+func (c *Client) doRequest(ctx context.Context, newRequest requestBuilder, tokenRetry bool) (*http.Response, error) {
+	var prevDelay time.Duration
+	var lastFailure *NepseError // Failure of the previous attempt, if any
+	probing := isProbe(ctx)
+
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest(ctx)
+		if err != nil {
+			return nil, err
+		}
+		endpoint := req.URL.RequestURI()
+		label := c.endpointLabel(endpoint)
+
+		if !probing && !c.allowCircuit(label) {
+			circuitErr := NewCircuitOpenError(label)
+			if lastFailure != nil {
+				// The circuit opened while retrying; keep the failure that caused it
+				circuitErr.Err = lastFailure
 			}
+			circuitErr.method, circuitErr.endpoint, circuitErr.attempts = req.Method, endpoint, attempt-1
+			return nil, circuitErr
 		}
-
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			lastErr = NewNetworkError(err)
-			continue
+		if err := c.waitForSlot(ctx, endpoint); err != nil {
+			c.recordCircuit(ctx, label, circuitIgnored)
+			return nil, err
 		}
 
-		// Retry on server errors and rate limits
-		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		info := RequestInfo{
+			Endpoint:   endpoint,
+			Name:       c.config.Endpoints.Name(endpoint),
+			Attempt:    attempt,
+			TokenRetry: tokenRetry,
+		}
+		c.metrics.RequestStarted(label, attempt)
+		start := time.Now()
+		resp, err := c.roundTrip(req.WithContext(withRequestInfo(ctx, info)))
+		latency := time.Since(start)
+		var failure *NepseError
+		if err == nil && resp == nil {
+			// A middleware returned neither a response nor an error
+			failure = NewInternalError("request returned no response", nil)
+			err = failure
+		} else if resp != nil && resp.Body == nil {
+			resp.Body = http.NoBody
+		}
+		c.logAttempt(req, info, resp, err, latency)
+		status := 0
+		if err == nil {
+			status = resp.StatusCode
+		}
+		c.metrics.RequestFinished(label, status, latency, attempt)
+
+		var retryAfter time.Duration
+		switch {
+		case failure != nil:
+			// No response to inspect
+		case err != nil:
+			failure = NewNetworkError(err)
+		default:
+			if resp.StatusCode == http.StatusTooManyRequests {
+				retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			}
+			c.observeRateLimit(endpoint, resp.StatusCode, retryAfter)
+			if resp.StatusCode < 400 {
+				c.recordCircuit(ctx, label, circuitSuccess)
+				return resp, nil
+			}
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+			failure = newResponseError(req.Method, endpoint, resourceName(info.Name, endpoint), resp.StatusCode, resp.Status, body)
+		}
+		failure.method, failure.endpoint, failure.attempts = req.Method, endpoint, attempt
+		c.recordCircuit(ctx, label, circuitOutcome(ctx, failure))
+
+		delay, retry := time.Duration(0), false
+		if !probing && status != http.StatusUnauthorized && attempt <= c.options.MaxRetries {
+			delay, retry = c.retryPolicy.Retry(RetryAttempt{
+				Method:    req.Method,
+				Endpoint:  endpoint,
+				Attempt:   attempt,
+				Response:  resp,
+				Err:       failure,
+				PrevDelay: prevDelay,
+			})
+		}
+		if resp != nil {
 			_ = resp.Body.Close()
-			lastErr = MapHTTPStatusToError(resp.StatusCode, resp.Status)
-			continue
 		}
+		if !retry {
+			return nil, failure
+		}
+		lastFailure = failure
+
+		// Honor the server's Retry-After if it asks for a longer pause
+		delay = max(delay, retryAfter)
+		prevDelay = delay
+		c.logger.LogAttrs(ctx, slog.LevelInfo, "retrying request",
+			slog.String("endpoint", endpoint),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("delay", delay),
+			slog.Any("error", failure),
+		)
+		c.metrics.RequestRetried(label, attempt+1, delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// tokenIsFresh reports whether the access token was issued within
+// blockedTokenAge, so refreshing it would not change a 403.
+func (c *Client) tokenIsFresh() bool {
+	age, ok := c.authManager.TokenAge()
+	return ok && age < blockedTokenAge
+}
+
+// isTokenRejection reports whether err is a 401 or 403 response, either of which
+// NEPSE sends for stale tokens.
+func isTokenRejection(err *NepseError) bool {
+	return err.statusCode == http.StatusUnauthorized || err.statusCode == http.StatusForbidden
+}
+
+// resourceName describes what a request asked for in error messages: the
+// Endpoints field name if known, otherwise the path.
+func resourceName(name, endpoint string) string {
+	if name != "" {
+		return name
+	}
+	path, _, _ := strings.Cut(endpoint, "?")
+	return path
+}
+
+// allowCircuit reports whether the endpoint's circuit lets a request through.
+func (c *Client) allowCircuit(label string) bool {
+	return c.breakers == nil || c.breakers.allow(label)
+}
 
-		return resp, nil
+// recordCircuit feeds an attempt's outcome into the endpoint's circuit and logs state changes.
+func (c *Client) recordCircuit(ctx context.Context, label string, result circuitResult) {
+	if c.breakers == nil || isProbe(ctx) {
+		return
 	}
+	from, to := c.breakers.record(label, result)
+	if from == to {
+		return
+	}
+	level := slog.LevelInfo
+	if to == CircuitOpen {
+		level = slog.LevelWarn
+	}
+	c.logger.LogAttrs(ctx, level, "circuit "+to.String(), slog.String("endpoint", label), slog.String("from", from.String()))
+}
+
+// circuitOutcome classifies a failed attempt: only network and server errors
+// count against the endpoint, and attempts abandoned by the caller are ignored.
+func circuitOutcome(ctx context.Context, failure *NepseError) circuitResult {
+	switch {
+	case ctx.Err() != nil:
+		return circuitIgnored
+	case failure.Type == ErrorTypeNetworkError, failure.Type == ErrorTypeInvalidServerResponse:
+		return circuitFailure
+	default:
+		return circuitSuccess
+	}
+}
+
+// logAttempt records the outcome of a single request attempt.
+func (c *Client) logAttempt(req *http.Request, info RequestInfo, resp *http.Response, err error, latency time.Duration) {
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("endpoint", info.Endpoint),
+		slog.Int("attempt", info.Attempt),
+		slog.Duration("latency", latency),
+	}
+	if info.Name != "" {
+		attrs = append(attrs, slog.String("name", info.Name))
+	}
+	if err != nil {
+		c.logger.LogAttrs(req.Context(), slog.LevelWarn, "request failed", append(attrs, slog.Any("error", err))...)
+		return
+	}
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	level := slog.LevelDebug
+	if resp.StatusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	c.logger.LogAttrs(req.Context(), level, "request completed", attrs...)
+}
+
+// decodeError builds the error for a response body that could not be decoded,
+// keeping the start of the body to show what NEPSE sent instead.
+func (c *Client) decodeError(method, endpoint string, data []byte, err error) *NepseError {
+	c.logDecodeFailure(endpoint, err)
+	e := NewInternalError("failed to decode response", err)
+	e.method, e.endpoint, e.body = method, endpoint, bodySnippet(data)
+	return e
+}
 
-	return nil, lastErr
+// logDecodeFailure records a response that could not be decoded, which usually
+// means NEPSE changed a response schema.
+func (c *Client) logDecodeFailure(endpoint string, err error) {
+	c.logger.Warn("failed to decode response", slog.String("endpoint", endpoint), slog.Any("error", err))
 }
 
 func (c *Client) setCommonHeaders(req *http.Request) {
@@ -135,36 +376,68 @@ func (c *Client) setCommonHeaders(req *http.Request) {
 	req.Header.Set("Referer", c.config.BaseURL+"/")
 }
 
-// doAuthenticatedRequest executes an authenticated API request with automatic token refresh on 401.
-func (c *Client) doAuthenticatedRequest(ctx context.Context, endpoint string, tokenRetry bool) (*http.Response, error) {
-	token, err := c.authManager.AccessToken(ctx)
-	if err != nil {
-		return nil, NewInternalError("failed to get access token", err)
-	}
-
+// doAuthenticatedRequest executes an authenticated API request with automatic
+// token refresh on 401, and on 403 unless the token was issued within
+// blockedTokenAge, in which case the endpoint is reported as blocked.
+// payload, if non-nil, is encoded as the JSON body of every attempt.
+func (c *Client) doAuthenticatedRequest(ctx context.Context, method, endpoint string, payload payloadFunc, tokenRetry bool) (*http.Response, error) {
 	url := c.config.BaseURL + endpoint
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
-	if err != nil {
-		return nil, NewInternalError("failed to create request", err)
-	}
+	resp, err := c.doRequest(ctx, func(ctx context.Context) (*http.Request, error) {
+		token, err := c.authManager.AccessToken(ctx)
+		if err != nil {
+			return nil, NewInternalError("failed to get access token", err)
+		}
 
-	auth.SetAuthHeader(req, token)
-	req.Header.Set("Accept", "application/json")
-	c.setCommonHeaders(req)
+		var body io.Reader
+		if payload != nil {
+			v, err := payload(ctx)
+			if err != nil {
+				return nil, err
+			}
+			data, err := json.Marshal(v)
+			if err != nil {
+				return nil, NewInternalError("failed to marshal request body", err)
+			}
+			body = bytes.NewReader(data)
+		}
 
-	resp, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, NewInternalError("failed to create request", err)
+		}
 
-	// Retry once on 401 with fresh token
-	if resp.StatusCode == http.StatusUnauthorized && !tokenRetry {
-		_ = resp.Body.Close()
+		auth.SetAuthHeader(req, token)
+		req.Header.Set("Accept", "application/json")
+		if method == http.MethodPost {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		c.setCommonHeaders(req)
+		return req, nil
+	}, tokenRetry)
+
+	// Retry once on 401/403 with fresh token
+	var nepseErr *NepseError
+	if errors.As(err, &nepseErr) && isTokenRejection(nepseErr) {
+		if tokenRetry || nepseErr.statusCode == http.StatusForbidden && c.tokenIsFresh() {
+			// A 403 with a token issued moments ago means the endpoint itself is blocked
+			if nepseErr.Type == ErrorTypeUnauthorized {
+				nepseErr.Type = ErrorTypeEndpointBlocked
+				nepseErr.Message = NewEndpointBlockedError(resourceName(c.config.Endpoints.Name(endpoint), endpoint)).Message
+			}
+			return nil, nepseErr
+		}
+		c.logger.Info("token rejected, forcing refresh",
+			slog.String("endpoint", endpoint),
+			slog.Int("status", nepseErr.statusCode),
+		)
 		if err := c.authManager.ForceUpdate(ctx); err != nil {
 			return nil, NewInternalError("failed to refresh token", err)
 		}
-		return c.doAuthenticatedRequest(ctx, endpoint, true)
+		return c.doAuthenticatedRequest(ctx, method, endpoint, payload, true)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -176,112 +449,93 @@ func (c *Client) doAuthenticatedRequest(ctx context.Context, endpoint string, to
 }
 
 func (c *Client) apiRequest(ctx context.Context, endpoint string, result any) error {
-	resp, err := c.doAuthenticatedRequest(ctx, endpoint, false)
+	data, err := c.apiRequestRaw(ctx, endpoint)
 	if err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return NewInternalError("failed to decode response", err)
+	if err := json.Unmarshal(data, result); err != nil {
+		return c.decodeError(http.MethodGet, endpoint, data, err)
 	}
 	return nil
 }
 
+// apiRequestRaw returns the raw response body for endpoint, serving it from the
+// cache when the endpoint's TTL policy allows.
 func (c *Client) apiRequestRaw(ctx context.Context, endpoint string) ([]byte, error) {
-	resp, err := c.doAuthenticatedRequest(ctx, endpoint, false)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	return io.ReadAll(resp.Body)
-}
-
-// DebugRawRequest makes an authenticated request and returns the raw response.
-// This is for debugging API responses.
-func (c *Client) DebugRawRequest(ctx context.Context, endpoint string) ([]byte, error) {
-	return c.apiRequestRaw(ctx, endpoint)
-}
-
-// doAuthenticatedPostRequest executes an authenticated POST API request.
-func (c *Client) doAuthenticatedPostRequest(ctx context.Context, endpoint string, body any, tokenRetry bool) (*http.Response, error) {
-	token, err := c.authManager.AccessToken(ctx)
-	if err != nil {
-		return nil, NewInternalError("failed to get access token", err)
-	}
-
-	var bodyReader io.Reader
-	if body != nil {
-		bodyBytes, err := json.Marshal(body)
-		if err != nil {
-			return nil, NewInternalError("failed to marshal request body", err)
+	ttl := c.cacheTTL(endpoint)
+	if ttl > 0 && !cacheBypassed(ctx) {
+		if data, ok := c.cache.Get(c.cacheKey(endpoint)); ok {
+			c.metrics.CacheHit(c.endpointLabel(endpoint))
+			return data, nil
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
+		c.metrics.CacheMiss(c.endpointLabel(endpoint))
 	}
 
-	url := c.config.BaseURL + endpoint
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bodyReader)
+	data, err := c.fetchRaw(ctx, endpoint)
 	if err != nil {
-		return nil, NewInternalError("failed to create request", err)
+		return nil, err
 	}
 
-	auth.SetAuthHeader(req, token)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	c.setCommonHeaders(req)
+	if ttl > 0 {
+		c.cache.Set(c.cacheKey(endpoint), data, ttl)
+	}
+	return data, nil
+}
 
-	resp, err := c.doRequest(req)
+// fetchRaw performs an uncached authenticated GET and returns the response body.
+func (c *Client) fetchRaw(ctx context.Context, endpoint string) ([]byte, error) {
+	resp, err := c.doAuthenticatedRequest(ctx, http.MethodGet, endpoint, nil, false)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { _ = resp.Body.Close() }()
 
-	// Retry once on 401 with fresh token
-	if resp.StatusCode == http.StatusUnauthorized && !tokenRetry {
-		_ = resp.Body.Close()
-		if err := c.authManager.ForceUpdate(ctx); err != nil {
-			return nil, NewInternalError("failed to refresh token", err)
-		}
-		return c.doAuthenticatedPostRequest(ctx, endpoint, body, true)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		_ = resp.Body.Close()
-		return nil, MapHTTPStatusToError(resp.StatusCode, resp.Status)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, NewNetworkError(err)
 	}
+	return data, nil
+}
 
-	return resp, nil
+// DebugRawRequest makes an authenticated request and returns the raw response.
+// This is for debugging API responses and always bypasses the cache.
+func (c *Client) DebugRawRequest(ctx context.Context, endpoint string) ([]byte, error) {
+	return c.fetchRaw(ctx, endpoint)
 }
 
 // apiPostRequest makes an authenticated POST request and decodes the JSON response.
-func (c *Client) apiPostRequest(ctx context.Context, endpoint string, body any, result any) error {
-	resp, err := c.doAuthenticatedPostRequest(ctx, endpoint, body, false)
+func (c *Client) apiPostRequest(ctx context.Context, endpoint string, payload payloadFunc, result any) error {
+	data, err := c.apiPostRequestRaw(ctx, endpoint, payload)
 	if err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return NewInternalError("failed to decode response", err)
+	if err := json.Unmarshal(data, result); err != nil {
+		return c.decodeError(http.MethodPost, endpoint, data, err)
 	}
 	return nil
 }
 
 // apiPostRequestRaw makes an authenticated POST request and returns raw bytes.
-func (c *Client) apiPostRequestRaw(ctx context.Context, endpoint string, body any) ([]byte, error) {
-	resp, err := c.doAuthenticatedPostRequest(ctx, endpoint, body, false)
+func (c *Client) apiPostRequestRaw(ctx context.Context, endpoint string, payload payloadFunc) ([]byte, error) {
+	resp, err := c.doAuthenticatedRequest(ctx, http.MethodPost, endpoint, payload, false)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	return io.ReadAll(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, NewNetworkError(err)
+	}
+	return data, nil
 }
 
 // DebugRawPostRequest makes an authenticated POST request and returns the raw response.
 // This is for debugging API responses.
 func (c *Client) DebugRawPostRequest(ctx context.Context, endpoint string, body any) ([]byte, error) {
-	return c.apiPostRequestRaw(ctx, endpoint, body)
+	return c.apiPostRequestRaw(ctx, endpoint, staticPayload(body))
 }
 
 // DebugDecodedToken returns the WASM-decoded access token for debugging.
@@ -1,15 +1,22 @@
 package nepse
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/itsbohara/go-nepse/internal/auth"
+	"github.com/itsbohara/go-nepse/nepsetest"
 )
 
 // newTestServer creates a mock NEPSE API server
@@ -17,6 +24,45 @@ func newTestServer(handler http.Handler) *httptest.Server {
 	return httptest.NewServer(handler)
 }
 
+// newTestClient serves handler from a mock NEPSE API server and returns a
+// client for it, both closed when the test ends. The options are minimal (no
+// retries, no cache) unless opts, applied in order, change them.
+func newTestClient(t *testing.T, handler http.Handler, opts ...func(*Options)) *Client {
+	t.Helper()
+	server := newTestServer(handler)
+	t.Cleanup(server.Close)
+
+	options := &Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	client, err := NewClient(options)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// withDefaults starts from DefaultOptions with the opt-in rate limiter and
+// circuit breaker enabled too, so tests exercise the full production stack
+// against the mock server.
+func withDefaults(o *Options) {
+	base, config := o.BaseURL, o.Config
+	*o = *DefaultOptions()
+	o.BaseURL, o.Config = base, config
+	o.RateLimit = DefaultRateLimit()
+	o.CircuitBreaker = DefaultCircuitBreaker()
+}
+
 // tokenResponse returns a valid token response JSON
 func tokenResponse() auth.TokenResponse {
 	return auth.TokenResponse{
@@ -530,6 +576,452 @@ func TestClient_DebugRawRequest(t *testing.T) {
 	}
 }
 
+func TestClient_Middleware(t *testing.T) {
+	var apiCallCount atomic.Int32
+	var capturedHeader string
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(tokenResponse())
+
+		case "/api/nots/nepse-data/market-open":
+			count := apiCallCount.Add(1)
+			switch count {
+			case 1:
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case 2:
+				w.WriteHeader(http.StatusUnauthorized)
+			default:
+				capturedHeader = r.Header.Get("X-Trace")
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]any{"isOpen": "OPEN"})
+			}
+
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	var seen []RequestInfo
+	recorder := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if info, ok := RequestInfoFromContext(req.Context()); ok && info.Name != "" {
+				seen = append(seen, info)
+			}
+			req.Header.Set("X-Trace", "abc")
+			return next(req)
+		}
+	}
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  1,
+		RetryDelay:  time.Millisecond,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+		Middleware: []Middleware{recorder},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.MarketStatus(context.Background()); err != nil {
+		t.Fatalf("MarketStatus() failed: %v", err)
+	}
+
+	want := []RequestInfo{
+		{Name: "MarketOpen", Attempt: 1, TokenRetry: false},
+		{Name: "MarketOpen", Attempt: 2, TokenRetry: false},
+		{Name: "MarketOpen", Attempt: 1, TokenRetry: true},
+	}
+	if len(seen) != len(want) {
+		t.Fatalf("middleware saw %d attempts, want %d: %+v", len(seen), len(want), seen)
+	}
+	for i, w := range want {
+		got := seen[i]
+		if got.Name != w.Name || got.Attempt != w.Attempt || got.TokenRetry != w.TokenRetry {
+			t.Errorf("attempt %d: got %+v, want %+v", i, got, w)
+		}
+		if got.Endpoint != "/api/nots/nepse-data/market-open" {
+			t.Errorf("attempt %d: endpoint = %q", i, got.Endpoint)
+		}
+	}
+	if capturedHeader != "abc" {
+		t.Errorf("expected middleware header to reach server, got %q", capturedHeader)
+	}
+}
+
+func TestClient_MiddlewareFaultInjection(t *testing.T) {
+	var serverCalls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverCalls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tokenResponse())
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	injected := errors.New("injected fault")
+	failFirst := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if info, _ := RequestInfoFromContext(req.Context()); info.Attempt == 1 {
+				return nil, injected
+			}
+			return next(req)
+		}
+	}
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  1,
+		RetryDelay:  time.Millisecond,
+		Config: &Config{
+			BaseURL: server.URL,
+		},
+		Middleware: []Middleware{failFirst},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Token(context.Background()); err != nil {
+		t.Fatalf("Token() should succeed on retry: %v", err)
+	}
+	if serverCalls.Load() != 1 {
+		t.Errorf("expected 1 server call after injected fault, got %d", serverCalls.Load())
+	}
+}
+
+func TestClient_MiddlewareIncompleteResponse(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("middleware should not call the server")
+	})
+	tests := []struct {
+		name string
+		resp *http.Response
+		want error
+	}{
+		{"nil response", nil, ErrInternal},
+		{"nil body", &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}, ErrInvalidServerResponse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, handler, func(o *Options) {
+				o.Middleware = []Middleware{func(RoundTripFunc) RoundTripFunc {
+					return func(*http.Request) (*http.Response, error) { return tt.resp, nil }
+				}}
+			})
+			if _, err := client.Token(context.Background()); !errors.Is(err, tt.want) {
+				t.Errorf("Token() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_StructuredLogging(t *testing.T) {
+	var callCount atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if callCount.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tokenResponse())
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  1,
+		RetryDelay:  time.Millisecond,
+		Config: &Config{
+			BaseURL: server.URL,
+		},
+		Logger: logger,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Token(context.Background()); err != nil {
+		t.Fatalf("Token() failed: %v", err)
+	}
+
+	var records []map[string]any
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var rec map[string]any
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatalf("invalid log record: %v", err)
+		}
+		records = append(records, rec)
+	}
+
+	wantMsgs := []string{"request completed", "retrying request", "request completed"}
+	if len(records) != len(wantMsgs) {
+		t.Fatalf("got %d log records, want %d: %v", len(records), len(wantMsgs), records)
+	}
+	for i, msg := range wantMsgs {
+		if records[i]["msg"] != msg {
+			t.Errorf("record %d msg = %v, want %q", i, records[i]["msg"], msg)
+		}
+		if records[i]["endpoint"] != "/api/authenticate/prove" {
+			t.Errorf("record %d endpoint = %v", i, records[i]["endpoint"])
+		}
+	}
+	if records[0]["status"] != float64(http.StatusServiceUnavailable) || records[0]["level"] != "WARN" {
+		t.Errorf("first attempt should log 503 at WARN, got %v", records[0])
+	}
+	if records[2]["status"] != float64(http.StatusOK) || records[2]["attempt"] != float64(2) {
+		t.Errorf("second attempt should log 200 with attempt=2, got %v", records[2])
+	}
+}
+
+func TestClient_PostRetryResendsBody(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/nepse-data/market-open":
+			json.NewEncoder(w).Encode(MarketStatus{IsOpen: "CLOSE", ID: 7})
+		case "/api/nots/security/131":
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			bodies = append(bodies, string(body))
+			n := len(bodies)
+			mu.Unlock()
+			if n == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(`{"security":{"id":131,"symbol":"NABIL"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  1,
+		RetryDelay:  time.Millisecond,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.SecurityDetail(ctx, 131); err != nil {
+		t.Fatalf("SecurityDetail failed: %v", err)
+	}
+
+	e, _, err := client.computeBasePayloadID(ctx)
+	if err != nil {
+		t.Fatalf("computeBasePayloadID failed: %v", err)
+	}
+	want := fmt.Sprintf(`{"id":%d}`, e)
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 POST attempts, got %d", len(bodies))
+	}
+	for i, body := range bodies {
+		if body != want {
+			t.Errorf("attempt %d body = %q, want %q", i+1, body, want)
+		}
+	}
+}
+
+func TestClient_GraphPayloadRecomputedAfterTokenRotation(t *testing.T) {
+	first := tokenResponse()
+	second := tokenResponse()
+	second.Salt1, second.Salt2, second.Salt3, second.Salt4 = 4321, 8765, 2109, 6543
+
+	var tokenCalls atomic.Int32
+	var mu sync.Mutex
+	var ids []int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			if tokenCalls.Add(1) == 1 {
+				json.NewEncoder(w).Encode(first)
+			} else {
+				json.NewEncoder(w).Encode(second)
+			}
+		case "/api/nots/nepse-data/market-open":
+			json.NewEncoder(w).Encode(MarketStatus{IsOpen: "CLOSE", ID: 42})
+		case "/api/nots/graph/index/58":
+			var payload graphPostPayload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("invalid POST body: %v", err)
+			}
+			mu.Lock()
+			ids = append(ids, payload.ID)
+			n := len(ids)
+			mu.Unlock()
+			if n == 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode([]GraphDataPoint{})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.DailyNepseIndexGraph(ctx); err != nil {
+		t.Fatalf("DailyNepseIndexGraph failed: %v", err)
+	}
+
+	e, day, err := client.computeBasePayloadID(ctx)
+	if err != nil {
+		t.Fatalf("computeBasePayloadID failed: %v", err)
+	}
+	saltsOf := func(tr auth.TokenResponse) auth.Salts {
+		return auth.Salts{Salt1: tr.Salt1, Salt2: tr.Salt2, Salt3: tr.Salt3, Salt4: tr.Salt4, Salt5: tr.Salt5}
+	}
+	want := []int{
+		indexGraphPayloadID(e, day, saltsOf(first)),
+		indexGraphPayloadID(e, day, saltsOf(second)),
+	}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %d POST attempts, got %d", len(want), len(ids))
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("attempt %d payload ID = %d, want %d", i+1, ids[i], want[i])
+		}
+	}
+	if ids[0] == ids[1] {
+		t.Error("payload ID should change when the token rotates")
+	}
+}
+
+func TestClient_GraphPayloadUsesNepalDay(t *testing.T) {
+	clock := nepsetest.NewClock(time.Time{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			resp := tokenResponse()
+			resp.ServerTime = clock.Now().UnixMilli()
+			json.NewEncoder(w).Encode(resp)
+		case "/api/nots/nepse-data/market-open":
+			json.NewEncoder(w).Encode(MarketStatus{IsOpen: "CLOSE", ID: 42})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+		Clock: clock,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		now     time.Time
+		wantDay int
+	}{
+		{time.Date(2026, 1, 4, 18, 14, 0, 0, time.UTC), 4}, // 23:59 NPT
+		{time.Date(2026, 1, 4, 18, 15, 0, 0, time.UTC), 5}, // midnight NPT, still the 4th in UTC
+	}
+	for _, tt := range tests {
+		clock.Set(tt.now)
+		e, day, err := client.computeBasePayloadID(context.Background())
+		if err != nil {
+			t.Fatalf("computeBasePayloadID failed: %v", err)
+		}
+		if day != tt.wantDay || e != dummyData[42]+42+2*tt.wantDay {
+			t.Errorf("at %v: day = %d, e = %d; want day %d", tt.now, day, e, tt.wantDay)
+		}
+	}
+}
+
+func TestClient_GraphPayloadUsesServerDay(t *testing.T) {
+	// The local clock reads 23:59 NPT, but NEPSE is already two minutes into the 5th
+	local := time.Date(2026, 1, 4, 18, 14, 0, 0, time.UTC)
+	server := local.Add(2 * time.Minute)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			resp := tokenResponse()
+			resp.ServerTime = server.UnixMilli()
+			json.NewEncoder(w).Encode(resp)
+		case "/api/nots/nepse-data/market-open":
+			json.NewEncoder(w).Encode(MarketStatus{IsOpen: "CLOSE", ID: 42})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	client := newTestClient(t, handler, func(o *Options) { o.Clock = nepsetest.NewClock(local) })
+
+	_, day, err := client.computeBasePayloadID(context.Background())
+	if err != nil {
+		t.Fatalf("computeBasePayloadID failed: %v", err)
+	}
+	if day != 5 {
+		t.Errorf("day = %d, want 5 from the server clock", day)
+	}
+	if skew := client.ClockSkew(); skew != 2*time.Minute {
+		t.Errorf("ClockSkew = %v, want 2m", skew)
+	}
+}
+
 // Benchmark for transport layer
 func BenchmarkClient_TokenFetch(b *testing.B) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
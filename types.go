@@ -1,6 +1,12 @@
 package nepse
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/itsbohara/go-nepse/bs"
+)
 
 // MarketSummaryItem represents a single item in the market summary response.
 type MarketSummaryItem struct {
@@ -20,60 +26,60 @@ type MarketSummary struct {
 
 // MarketStatus represents the current market status.
 type MarketStatus struct {
-	IsOpen string `json:"isOpen"`
-	AsOf   string `json:"asOf"`
-	ID     int32  `json:"id"`
+	IsOpen string   `json:"isOpen"`
+	AsOf   DateTime `json:"asOf"`
+	ID     int32    `json:"id"`
 }
 
 // IsMarketOpen returns true if the market is currently open.
 func (m *MarketStatus) IsMarketOpen() bool {
-	return m.IsOpen == "OPEN"
+	return m.Session() == SessionOpen
 }
 
 // NepseIndexRaw represents the raw NEPSE index response item.
 type NepseIndexRaw struct {
-	ID               int32   `json:"id"`
-	Index            string  `json:"index"`
-	Close            float64 `json:"close"`
-	High             float64 `json:"high"`
-	Low              float64 `json:"low"`
-	PreviousClose    float64 `json:"previousClose"`
-	Change           float64 `json:"change"`
-	PerChange        float64 `json:"perChange"`
-	FiftyTwoWeekHigh float64 `json:"fiftyTwoWeekHigh"`
-	FiftyTwoWeekLow  float64 `json:"fiftyTwoWeekLow"`
-	CurrentValue     float64 `json:"currentValue"`
-	GeneratedTime    string  `json:"generatedTime"`
+	ID               int32    `json:"id"`
+	Index            string   `json:"index"`
+	Close            float64  `json:"close"`
+	High             float64  `json:"high"`
+	Low              float64  `json:"low"`
+	PreviousClose    float64  `json:"previousClose"`
+	Change           float64  `json:"change"`
+	PerChange        float64  `json:"perChange"`
+	FiftyTwoWeekHigh float64  `json:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekLow  float64  `json:"fiftyTwoWeekLow"`
+	CurrentValue     float64  `json:"currentValue"`
+	GeneratedTime    DateTime `json:"generatedTime"`
 }
 
 // NepseIndex represents the NEPSE main index (ID 58).
 type NepseIndex struct {
-	IndexValue       float64 `json:"close"`
-	PercentChange    float64 `json:"perChange"`
-	PointChange      float64 `json:"change"`
-	High             float64 `json:"high"`
-	Low              float64 `json:"low"`
-	PreviousClose    float64 `json:"previousClose"`
-	FiftyTwoWeekHigh float64 `json:"fiftyTwoWeekHigh"`
-	FiftyTwoWeekLow  float64 `json:"fiftyTwoWeekLow"`
-	CurrentValue     float64 `json:"currentValue"`
-	GeneratedTime    string  `json:"generatedTime"`
+	IndexValue       float64  `json:"close"`
+	PercentChange    float64  `json:"perChange"`
+	PointChange      float64  `json:"change"`
+	High             float64  `json:"high"`
+	Low              float64  `json:"low"`
+	PreviousClose    float64  `json:"previousClose"`
+	FiftyTwoWeekHigh float64  `json:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekLow  float64  `json:"fiftyTwoWeekLow"`
+	CurrentValue     float64  `json:"currentValue"`
+	GeneratedTime    DateTime `json:"generatedTime"`
 }
 
 // SubIndex represents a sector sub-index.
 type SubIndex struct {
-	ID               int32   `json:"id"`
-	Index            string  `json:"index"`
-	Close            float64 `json:"close"`
-	High             float64 `json:"high"`
-	Low              float64 `json:"low"`
-	PreviousClose    float64 `json:"previousClose"`
-	Change           float64 `json:"change"`
-	PerChange        float64 `json:"perChange"`
-	FiftyTwoWeekHigh float64 `json:"fiftyTwoWeekHigh"`
-	FiftyTwoWeekLow  float64 `json:"fiftyTwoWeekLow"`
-	CurrentValue     float64 `json:"currentValue"`
-	GeneratedTime    string  `json:"generatedTime"`
+	ID               int32    `json:"id"`
+	Index            string   `json:"index"`
+	Close            float64  `json:"close"`
+	High             float64  `json:"high"`
+	Low              float64  `json:"low"`
+	PreviousClose    float64  `json:"previousClose"`
+	Change           float64  `json:"change"`
+	PerChange        float64  `json:"perChange"`
+	FiftyTwoWeekHigh float64  `json:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekLow  float64  `json:"fiftyTwoWeekLow"`
+	CurrentValue     float64  `json:"currentValue"`
+	GeneratedTime    DateTime `json:"generatedTime"`
 }
 
 // Security represents a listed security/company.
@@ -116,14 +122,14 @@ type ParsedValue struct {
 
 // ShareGroup represents the share group classification.
 type ShareGroup struct {
-	ID              int32   `json:"id"`
-	Name            string  `json:"name"`
-	Description     string  `json:"description"`
-	CapitalRangeMin int64   `json:"capitalRangeMin"`
-	ModifiedBy      *string `json:"modifiedBy"`
-	ModifiedDate    *string `json:"modifiedDate"`
-	ActiveStatus    string  `json:"activeStatus"`
-	IsDefault       string  `json:"isDefault"`
+	ID              int32    `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	CapitalRangeMin int64    `json:"capitalRangeMin"`
+	ModifiedBy      *string  `json:"modifiedBy"`
+	ModifiedDate    DateTime `json:"modifiedDate"`
+	ActiveStatus    string   `json:"activeStatus"`
+	IsDefault       string   `json:"isDefault"`
 }
 
 // SectorMaster represents sector information.
@@ -162,17 +168,34 @@ type TodayPrice struct {
 	DifferenceRs        float64 `json:"differenceRs"`
 	PercentageChange    float64 `json:"percentageChange"`
 	TotalTrades         int32   `json:"totalTrades"`
-	BusinessDate        string  `json:"businessDate"`
+	BusinessDate        Date    `json:"businessDate"`
 	SecurityID          int32   `json:"securityId"`
 	LastTradedPrice     float64 `json:"lastTradedPrice"`
 	MaxPrice            float64 `json:"maxPrice"`
 	MinPrice            float64 `json:"minPrice"`
+
+	// Decimal holds the exact amounts when [Options.DecimalPrices] is set.
+	Decimal *TodayPriceDecimal `json:"-"`
+}
+
+// TodayPriceDecimal holds the amounts of a [TodayPrice] as exact decimals.
+type TodayPriceDecimal struct {
+	OpenPrice        Price `json:"openPrice"`
+	HighPrice        Price `json:"highPrice"`
+	LowPrice         Price `json:"lowPrice"`
+	ClosePrice       Price `json:"closePrice"`
+	TotalTradedValue Money `json:"totalTradedValue"`
+	PreviousClose    Price `json:"previousClose"`
+	DifferenceRs     Money `json:"differenceRs"`
+	LastTradedPrice  Price `json:"lastTradedPrice"`
+	MaxPrice         Price `json:"maxPrice"`
+	MinPrice         Price `json:"minPrice"`
 }
 
 // PriceHistory represents historical OHLCV data for a security.
 // Note: NEPSE API does not provide open price in historical data.
 type PriceHistory struct {
-	BusinessDate        string  `json:"businessDate"`
+	BusinessDate        Date    `json:"businessDate"`
 	HighPrice           float64 `json:"highPrice"`
 	LowPrice            float64 `json:"lowPrice"`
 	ClosePrice          float64 `json:"closePrice"`
@@ -183,34 +206,34 @@ type PriceHistory struct {
 
 // FloorSheetEntry represents a single floor sheet entry.
 type FloorSheetEntry struct {
-	ContractID       int64   `json:"contractId"`
-	StockSymbol      string  `json:"stockSymbol"`
-	SecurityName     string  `json:"securityName"`
-	BuyerMemberID    int32   `json:"buyerMemberId"`
-	SellerMemberID   int32   `json:"sellerMemberId"`
-	ContractQuantity int64   `json:"contractQuantity"`
-	ContractRate     float64 `json:"contractRate"`
-	BusinessDate     string  `json:"businessDate"`
-	TradeTime        string  `json:"tradeTime"`
-	SecurityID       int32   `json:"securityId"`
-	ContractAmount   float64 `json:"contractAmount"`
-	BuyerBrokerName  string  `json:"buyerBrokerName"`
-	SellerBrokerName string  `json:"sellerBrokerName"`
-	TradeBookID      int64   `json:"tradeBookId"`
+	ContractID       int64    `json:"contractId"`
+	StockSymbol      string   `json:"stockSymbol"`
+	SecurityName     string   `json:"securityName"`
+	BuyerMemberID    int32    `json:"buyerMemberId"`
+	SellerMemberID   int32    `json:"sellerMemberId"`
+	ContractQuantity int64    `json:"contractQuantity"`
+	ContractRate     float64  `json:"contractRate"`
+	BusinessDate     Date     `json:"businessDate"`
+	TradeTime        DateTime `json:"tradeTime"`
+	SecurityID       int32    `json:"securityId"`
+	ContractAmount   float64  `json:"contractAmount"`
+	BuyerBrokerName  string   `json:"buyerBrokerName"`
+	SellerBrokerName string   `json:"sellerBrokerName"`
+	TradeBookID      int64    `json:"tradeBookId"`
+
+	// Decimal holds the exact amounts when [Options.DecimalPrices] is set.
+	Decimal *FloorSheetDecimal `json:"-"`
+}
+
+// FloorSheetDecimal holds the amounts of a [FloorSheetEntry] as exact decimals.
+type FloorSheetDecimal struct {
+	ContractRate   Price `json:"contractRate"`
+	ContractAmount Money `json:"contractAmount"`
 }
 
 // FloorSheetResponse represents the paginated floor sheet response.
 type FloorSheetResponse struct {
-	FloorSheets struct {
-		Content          []FloorSheetEntry `json:"content"`
-		PageNumber       int32             `json:"number"`
-		Size             int32             `json:"size"`
-		TotalElements    int64             `json:"totalElements"`
-		TotalPages       int32             `json:"totalPages"`
-		First            bool              `json:"first"`
-		Last             bool              `json:"last"`
-		NumberOfElements int32             `json:"numberOfElements"`
-	} `json:"floorsheets"`
+	FloorSheets PaginatedResponse[FloorSheetEntry] `json:"floorsheets"`
 }
 
 // DepthEntry represents a single entry in market depth.
@@ -335,19 +358,19 @@ type GraphResponse struct {
 // CompanyDetailsRaw represents the raw nested company details response.
 type CompanyDetailsRaw struct {
 	SecurityMcsData struct {
-		SecurityID          string  `json:"securityId"`
-		OpenPrice           float64 `json:"openPrice"`
-		HighPrice           float64 `json:"highPrice"`
-		LowPrice            float64 `json:"lowPrice"`
-		TotalTradeQuantity  int64   `json:"totalTradeQuantity"`
-		TotalTrades         int32   `json:"totalTrades"`
-		LastTradedPrice     float64 `json:"lastTradedPrice"`
-		PreviousClose       float64 `json:"previousClose"`
-		BusinessDate        string  `json:"businessDate"`
-		ClosePrice          float64 `json:"closePrice"`
-		FiftyTwoWeekHigh    float64 `json:"fiftyTwoWeekHigh"`
-		FiftyTwoWeekLow     float64 `json:"fiftyTwoWeekLow"`
-		LastUpdatedDateTime string  `json:"lastUpdatedDateTime"`
+		SecurityID          string   `json:"securityId"`
+		OpenPrice           float64  `json:"openPrice"`
+		HighPrice           float64  `json:"highPrice"`
+		LowPrice            float64  `json:"lowPrice"`
+		TotalTradeQuantity  int64    `json:"totalTradeQuantity"`
+		TotalTrades         int32    `json:"totalTrades"`
+		LastTradedPrice     float64  `json:"lastTradedPrice"`
+		PreviousClose       float64  `json:"previousClose"`
+		BusinessDate        Date     `json:"businessDate"`
+		ClosePrice          float64  `json:"closePrice"`
+		FiftyTwoWeekHigh    float64  `json:"fiftyTwoWeekHigh"`
+		FiftyTwoWeekLow     float64  `json:"fiftyTwoWeekLow"`
+		LastUpdatedDateTime DateTime `json:"lastUpdatedDateTime"`
 	} `json:"securityMcsData"`
 	SecurityData struct {
 		ID               int32  `json:"id"`
@@ -370,18 +393,18 @@ type CompanyDetails struct {
 	ActiveStatus     string `json:"activeStatus"`
 	PermittedToTrade string `json:"permittedToTrade"`
 
-	OpenPrice           float64 `json:"openPrice"`
-	HighPrice           float64 `json:"highPrice"`
-	LowPrice            float64 `json:"lowPrice"`
-	ClosePrice          float64 `json:"closePrice"`
-	LastTradedPrice     float64 `json:"lastTradedPrice"`
-	PreviousClose       float64 `json:"previousClose"`
-	TotalTradeQuantity  int64   `json:"totalTradeQuantity"`
-	TotalTrades         int32   `json:"totalTrades"`
-	FiftyTwoWeekHigh    float64 `json:"fiftyTwoWeekHigh"`
-	FiftyTwoWeekLow     float64 `json:"fiftyTwoWeekLow"`
-	BusinessDate        string  `json:"businessDate"`
-	LastUpdatedDateTime string  `json:"lastUpdatedDateTime"`
+	OpenPrice           float64  `json:"openPrice"`
+	HighPrice           float64  `json:"highPrice"`
+	LowPrice            float64  `json:"lowPrice"`
+	ClosePrice          float64  `json:"closePrice"`
+	LastTradedPrice     float64  `json:"lastTradedPrice"`
+	PreviousClose       float64  `json:"previousClose"`
+	TotalTradeQuantity  int64    `json:"totalTradeQuantity"`
+	TotalTrades         int32    `json:"totalTrades"`
+	FiftyTwoWeekHigh    float64  `json:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekLow     float64  `json:"fiftyTwoWeekLow"`
+	BusinessDate        Date     `json:"businessDate"`
+	LastUpdatedDateTime DateTime `json:"lastUpdatedDateTime"`
 }
 
 // SecurityDetailRaw represents the raw response from POST /api/nots/security/{id}.
@@ -395,19 +418,19 @@ type SecurityDetailRaw struct {
 		FaceValue        float64 `json:"faceValue"`
 	} `json:"security"`
 	SecurityDailyTradeDTO struct {
-		SecurityID          string  `json:"securityId"`
-		OpenPrice           float64 `json:"openPrice"`
-		HighPrice           float64 `json:"highPrice"`
-		LowPrice            float64 `json:"lowPrice"`
-		ClosePrice          float64 `json:"closePrice"`
-		TotalTradeQuantity  int64   `json:"totalTradeQuantity"`
-		TotalTrades         int32   `json:"totalTrades"`
-		LastTradedPrice     float64 `json:"lastTradedPrice"`
-		PreviousClose       float64 `json:"previousClose"`
-		FiftyTwoWeekHigh    float64 `json:"fiftyTwoWeekHigh"`
-		FiftyTwoWeekLow     float64 `json:"fiftyTwoWeekLow"`
-		LastUpdatedDateTime string  `json:"lastUpdatedDateTime"`
-		BusinessDate        string  `json:"businessDate"`
+		SecurityID          string   `json:"securityId"`
+		OpenPrice           float64  `json:"openPrice"`
+		HighPrice           float64  `json:"highPrice"`
+		LowPrice            float64  `json:"lowPrice"`
+		ClosePrice          float64  `json:"closePrice"`
+		TotalTradeQuantity  int64    `json:"totalTradeQuantity"`
+		TotalTrades         int32    `json:"totalTrades"`
+		LastTradedPrice     float64  `json:"lastTradedPrice"`
+		PreviousClose       float64  `json:"previousClose"`
+		FiftyTwoWeekHigh    float64  `json:"fiftyTwoWeekHigh"`
+		FiftyTwoWeekLow     float64  `json:"fiftyTwoWeekLow"`
+		LastUpdatedDateTime DateTime `json:"lastUpdatedDateTime"`
+		BusinessDate        Date     `json:"businessDate"`
 	} `json:"securityDailyTradeDto"`
 
 	// Shareholding data at root level
@@ -442,36 +465,90 @@ type SecurityDetail struct {
 	PromoterPercent float64 `json:"promoterPercent"`
 
 	// Price data
-	OpenPrice           float64 `json:"openPrice"`
-	HighPrice           float64 `json:"highPrice"`
-	LowPrice            float64 `json:"lowPrice"`
-	ClosePrice          float64 `json:"closePrice"`
-	LastTradedPrice     float64 `json:"lastTradedPrice"`
-	PreviousClose       float64 `json:"previousClose"`
-	TotalTradedQuantity int64   `json:"totalTradedQuantity"`
-	TotalTrades         int32   `json:"totalTrades"`
-	FiftyTwoWeekHigh    float64 `json:"fiftyTwoWeekHigh"`
-	FiftyTwoWeekLow     float64 `json:"fiftyTwoWeekLow"`
-	BusinessDate        string  `json:"businessDate"`
-	LastUpdatedDateTime string  `json:"lastUpdatedDateTime"`
+	OpenPrice           float64  `json:"openPrice"`
+	HighPrice           float64  `json:"highPrice"`
+	LowPrice            float64  `json:"lowPrice"`
+	ClosePrice          float64  `json:"closePrice"`
+	LastTradedPrice     float64  `json:"lastTradedPrice"`
+	PreviousClose       float64  `json:"previousClose"`
+	TotalTradedQuantity int64    `json:"totalTradedQuantity"`
+	TotalTrades         int32    `json:"totalTrades"`
+	FiftyTwoWeekHigh    float64  `json:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekLow     float64  `json:"fiftyTwoWeekLow"`
+	BusinessDate        Date     `json:"businessDate"`
+	LastUpdatedDateTime DateTime `json:"lastUpdatedDateTime"`
+
+	// Decimal holds the exact amounts when [Options.DecimalPrices] is set.
+	Decimal *SecurityDetailDecimal `json:"-"`
+}
+
+// SecurityDetailDecimal holds the amounts of a [SecurityDetail] as exact decimals.
+type SecurityDetailDecimal struct {
+	FaceValue        Price `json:"faceValue"`
+	PaidUpCapital    Money `json:"paidUpCapital"`
+	IssuedCapital    Money `json:"issuedCapital"`
+	MarketCap        Money `json:"marketCap"`
+	OpenPrice        Price `json:"openPrice"`
+	HighPrice        Price `json:"highPrice"`
+	LowPrice         Price `json:"lowPrice"`
+	ClosePrice       Price `json:"closePrice"`
+	LastTradedPrice  Price `json:"lastTradedPrice"`
+	PreviousClose    Price `json:"previousClose"`
+	FiftyTwoWeekHigh Price `json:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekLow  Price `json:"fiftyTwoWeekLow"`
+}
+
+// securityDetailDecimalRaw mirrors the amounts of [SecurityDetailRaw].
+type securityDetailDecimalRaw struct {
+	Security struct {
+		FaceValue Price `json:"faceValue"`
+	} `json:"security"`
+	SecurityDailyTradeDTO struct {
+		OpenPrice        Price `json:"openPrice"`
+		HighPrice        Price `json:"highPrice"`
+		LowPrice         Price `json:"lowPrice"`
+		ClosePrice       Price `json:"closePrice"`
+		LastTradedPrice  Price `json:"lastTradedPrice"`
+		PreviousClose    Price `json:"previousClose"`
+		FiftyTwoWeekHigh Price `json:"fiftyTwoWeekHigh"`
+		FiftyTwoWeekLow  Price `json:"fiftyTwoWeekLow"`
+	} `json:"securityDailyTradeDto"`
+	PaidUpCapital        Money `json:"paidUpCapital"`
+	IssuedCapital        Money `json:"issuedCapital"`
+	MarketCapitalization Money `json:"marketCapitalization"`
 }
 
 // LiveMarketEntry represents live market data entry.
 type LiveMarketEntry struct {
-	SecurityID          string  `json:"securityId"`
-	Symbol              string  `json:"symbol"`
-	SecurityName        string  `json:"securityName"`
-	OpenPrice           float64 `json:"openPrice"`
-	HighPrice           float64 `json:"highPrice"`
-	LowPrice            float64 `json:"lowPrice"`
-	LastTradedPrice     float64 `json:"lastTradedPrice"`
-	TotalTradeQuantity  int64   `json:"totalTradeQuantity"`
-	TotalTradeValue     float64 `json:"totalTradeValue"`
-	PreviousClose       float64 `json:"previousClose"`
-	PercentageChange    float64 `json:"percentageChange"`
-	LastTradedVolume    int64   `json:"lastTradedVolume"`
-	LastUpdatedDateTime string  `json:"lastUpdatedDateTime"`
-	AverageTradedPrice  float64 `json:"averageTradedPrice"`
+	SecurityID          string   `json:"securityId"`
+	Symbol              string   `json:"symbol"`
+	SecurityName        string   `json:"securityName"`
+	OpenPrice           float64  `json:"openPrice"`
+	HighPrice           float64  `json:"highPrice"`
+	LowPrice            float64  `json:"lowPrice"`
+	LastTradedPrice     float64  `json:"lastTradedPrice"`
+	TotalTradeQuantity  int64    `json:"totalTradeQuantity"`
+	TotalTradeValue     float64  `json:"totalTradeValue"`
+	PreviousClose       float64  `json:"previousClose"`
+	PercentageChange    float64  `json:"percentageChange"`
+	LastTradedVolume    int64    `json:"lastTradedVolume"`
+	LastUpdatedDateTime DateTime `json:"lastUpdatedDateTime"`
+	AverageTradedPrice  float64  `json:"averageTradedPrice"`
+
+	// Decimal holds the exact amounts when [Options.DecimalPrices] is set.
+	Decimal *LiveMarketDecimal `json:"-"`
+}
+
+// LiveMarketDecimal holds the amounts of a [LiveMarketEntry] as exact
+// decimals. AverageTradedPrice is rounded to the nearest paisa.
+type LiveMarketDecimal struct {
+	OpenPrice          Price `json:"openPrice"`
+	HighPrice          Price `json:"highPrice"`
+	LowPrice           Price `json:"lowPrice"`
+	LastTradedPrice    Price `json:"lastTradedPrice"`
+	TotalTradeValue    Money `json:"totalTradeValue"`
+	PreviousClose      Price `json:"previousClose"`
+	AverageTradedPrice Price `json:"averageTradedPrice"`
 }
 
 // SectorScrips represents scrips grouped by sector.
@@ -525,7 +602,7 @@ func (b *BoardMember) FullName() string {
 type CorporateAction struct {
 	ActiveStatus          string   `json:"activeStatus"`
 	AuthorizationComments *string  `json:"authorizationComments"`
-	SubmittedDate         string   `json:"submittedDate"`
+	SubmittedDate         DateTime `json:"submittedDate"`
 	FilePath              string   `json:"filePath"`
 	DocumentID            int32    `json:"documentId"`
 	RatioNum              float64  `json:"ratioNum"`
@@ -562,6 +639,19 @@ type FinancialYear struct {
 	ToYear       string `json:"toYear"`
 }
 
+// BS parses the fiscal year, preferring the Nepali (Bikram Sambat) name.
+func (f *FinancialYear) BS() (bs.FiscalYear, error) {
+	if fy, err := bs.ParseFiscalYear(f.FYNameNepali); err == nil {
+		return fy, nil
+	}
+	// FYName is the Gregorian span; the BS fiscal year starts 57 years later.
+	fy, err := bs.ParseFiscalYear(f.FYName)
+	if err != nil {
+		return 0, fmt.Errorf("nepse: unrecognized fiscal year %q / %q", f.FYNameNepali, f.FYName)
+	}
+	return fy + 57, nil
+}
+
 // QuarterMaster represents a fiscal quarter.
 type QuarterMaster struct {
 	ID          int32  `json:"id"`
@@ -590,17 +680,17 @@ type FiscalReport struct {
 
 // ReportDocument represents a document attached to a report.
 type ReportDocument struct {
-	ID            int32  `json:"id"`
-	SubmittedDate string `json:"submittedDate"`
-	FilePath      string `json:"filePath"`
-	EncryptedID   string `json:"encryptedId"`
+	ID            int32    `json:"id"`
+	SubmittedDate DateTime `json:"submittedDate"`
+	FilePath      string   `json:"filePath"`
+	EncryptedID   string   `json:"encryptedId"`
 }
 
 // Report represents a quarterly or annual financial report.
 type Report struct {
 	ID                             int32            `json:"id"`
 	ActiveStatus                   string           `json:"activeStatus"`
-	ModifiedDate                   string           `json:"modifiedDate"`
+	ModifiedDate                   DateTime         `json:"modifiedDate"`
 	ApplicationType                int32            `json:"applicationType"`
 	ApplicationStatus              int32            `json:"applicationStatus"`
 	FiscalReport                   *FiscalReport    `json:"fiscalReport"`
@@ -631,6 +721,35 @@ func (r *Report) QuarterName() string {
 	return ""
 }
 
+// FiscalPeriod returns the Bikram Sambat fiscal year and quarter the report
+// covers. The quarter is 0 for annual reports.
+func (r *Report) FiscalPeriod() (bs.FiscalYear, bs.Quarter, error) {
+	if r.FiscalReport == nil || r.FiscalReport.FinancialYear == nil {
+		return 0, 0, fmt.Errorf("nepse: report %d has no financial year", r.ID)
+	}
+	fy, err := r.FiscalReport.FinancialYear.BS()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var quarter bs.Quarter
+	if r.IsQuarterly() {
+		switch name := strings.ToLower(r.QuarterName()); {
+		case strings.HasPrefix(name, "first"):
+			quarter = 1
+		case strings.HasPrefix(name, "second"):
+			quarter = 2
+		case strings.HasPrefix(name, "third"):
+			quarter = 3
+		case strings.HasPrefix(name, "fourth"):
+			quarter = 4
+		default:
+			return 0, 0, fmt.Errorf("nepse: unrecognized quarter %q", r.QuarterName())
+		}
+	}
+	return fy, quarter, nil
+}
+
 // DividendNotice contains dividend declaration details.
 type DividendNotice struct {
 	ID            int32          `json:"id"`
@@ -648,7 +767,7 @@ type CompanyNews struct {
 	NewsHeadline    string          `json:"newsHeadline"`
 	NewsBody        string          `json:"newsBody"`
 	NewsType        string          `json:"newsType"`
-	ExpiryDate      string          `json:"expiryDate"`
+	ExpiryDate      Date            `json:"expiryDate"`
 	DividendsNotice *DividendNotice `json:"dividendsNotice"`
 }
 
@@ -656,7 +775,7 @@ type CompanyNews struct {
 type Dividend struct {
 	ID                int32        `json:"id"`
 	ActiveStatus      string       `json:"activeStatus"`
-	ModifiedDate      string       `json:"modifiedDate"`
+	ModifiedDate      DateTime     `json:"modifiedDate"`
 	ApplicationType   int32        `json:"applicationType"`
 	ApplicationStatus int32        `json:"applicationStatus"`
 	CompanyNews       *CompanyNews `json:"companyNews"`
@@ -701,3 +820,11 @@ func (d *Dividend) FiscalYear() string {
 	}
 	return ""
 }
+
+// BSFiscalYear returns the fiscal year of the dividend as a [bs.FiscalYear].
+func (d *Dividend) BSFiscalYear() (bs.FiscalYear, error) {
+	if d.CompanyNews != nil && d.CompanyNews.DividendsNotice != nil && d.CompanyNews.DividendsNotice.FinancialYear != nil {
+		return d.CompanyNews.DividendsNotice.FinancialYear.BS()
+	}
+	return 0, fmt.Errorf("nepse: dividend %d has no financial year", d.ID)
+}
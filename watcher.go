@@ -0,0 +1,355 @@
+package nepse
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Watcher polling defaults.
+const (
+	// DefaultWatchInterval matches how often NEPSE refreshes the live market.
+	DefaultWatchInterval = 5 * time.Second
+	// DefaultClosedInterval is how often a paused Watcher checks whether the market has opened.
+	DefaultClosedInterval = time.Minute
+)
+
+// EventType identifies what changed for a security between two live market snapshots.
+type EventType int
+
+const (
+	EventTrade        EventType = iota + 1 // A trade was printed since the last snapshot
+	EventPriceChange                       // LastTradedPrice changed
+	EventVolumeChange                      // TotalTradeQuantity changed
+	EventNewHigh                           // HighPrice rose to a new high of the day
+	EventNewLow                            // LowPrice fell to a new low of the day
+)
+
+// String returns the event type name.
+func (t EventType) String() string {
+	switch t {
+	case EventTrade:
+		return "trade"
+	case EventPriceChange:
+		return "price_change"
+	case EventVolumeChange:
+		return "volume_change"
+	case EventNewHigh:
+		return "new_high"
+	case EventNewLow:
+		return "new_low"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a change to one security between two live market snapshots.
+type Event struct {
+	Type     EventType
+	Symbol   string
+	Previous *LiveMarketEntry // Nil when the security first traded in this snapshot
+	Current  LiveMarketEntry
+	Time     time.Time // When the snapshot was fetched
+}
+
+// WatchFilter selects the events a [Subscription] receives. Empty fields match
+// everything; symbols and sectors are compared case-insensitively.
+type WatchFilter struct {
+	Symbols []string
+	Sectors []string // Sector names as reported by [Client.Companies]
+	Types   []EventType
+}
+
+// Subscription receives a [Watcher]'s events on C. Events are dropped rather
+// than stalling the watcher when C's buffer is full.
+type Subscription struct {
+	C <-chan Event
+
+	ch      chan Event
+	filter  WatchFilter
+	watcher *Watcher
+	dropped atomic.Int64
+}
+
+// Dropped returns how many events were discarded because C was full.
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close stops delivery and closes C. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.watcher.unsubscribe(s)
+}
+
+// WatcherOptions configures a [Watcher].
+type WatcherOptions struct {
+	// Interval between live market polls while the market is open. Polls are
+	// aligned to multiples of Interval on the client's [Clock]. Zero uses
+	// DefaultWatchInterval.
+	Interval time.Duration
+	// ClosedInterval between market status checks while the market is closed.
+	// Zero uses DefaultClosedInterval.
+	ClosedInterval time.Duration
+}
+
+// Watcher polls [Client.LiveMarket] and emits typed per-security events by
+// diffing consecutive snapshots by SecurityID. It pauses while [Client.MarketStatus]
+// reports the market closed; the first snapshot after starting or reopening
+// is a baseline and produces no events.
+type Watcher struct {
+	client         *Client
+	interval       time.Duration
+	closedInterval time.Duration
+
+	mu      sync.Mutex
+	subs    []*Subscription
+	paused  bool
+	closed  bool
+	sectors map[string]string // symbol -> upper-case sector name
+}
+
+// NewWatcher returns a watcher over client's live market. opts may be nil.
+func NewWatcher(client *Client, opts *WatcherOptions) *Watcher {
+	w := &Watcher{
+		client:         client,
+		interval:       DefaultWatchInterval,
+		closedInterval: DefaultClosedInterval,
+		sectors:        make(map[string]string),
+	}
+	if opts != nil {
+		if opts.Interval > 0 {
+			w.interval = opts.Interval
+		}
+		if opts.ClosedInterval > 0 {
+			w.closedInterval = opts.ClosedInterval
+		}
+	}
+	return w
+}
+
+// Subscribe registers a subscription whose channel buffers up to buffer events.
+func (w *Watcher) Subscribe(filter WatchFilter, buffer int) *Subscription {
+	ch := make(chan Event, buffer)
+	s := &Subscription{C: ch, ch: ch, filter: normalizeFilter(filter), watcher: w}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		close(ch)
+		return s
+	}
+	w.subs = append(w.subs, s)
+	return s
+}
+
+func (w *Watcher) unsubscribe(s *Subscription) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if i := slices.Index(w.subs, s); i >= 0 {
+		w.subs = slices.Delete(w.subs, i, i+1)
+		close(s.ch)
+	}
+}
+
+// Paused reports whether the watcher is waiting for the market to open.
+func (w *Watcher) Paused() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.paused
+}
+
+// Run polls until ctx is done, then closes every subscription and returns
+// ctx's error. Failed polls are logged and retried on the next interval.
+// A Watcher runs once; subscriptions made after Run returns are closed immediately.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer w.closeAll()
+
+	var prev map[string]LiveMarketEntry
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		status, err := w.client.MarketStatus(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			w.client.logger.Warn("watcher market status check failed", slog.Any("error", err))
+			timer.Reset(w.untilNextPoll(w.client.clock.Now()))
+			continue
+		case !status.IsMarketOpen():
+			// Yesterday's snapshot must not be diffed against the next session.
+			prev = nil
+			w.setPaused(true)
+			timer.Reset(w.closedInterval)
+			continue
+		}
+		w.setPaused(false)
+
+		entries, err := w.client.LiveMarket(withoutCache(ctx))
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			w.client.logger.Warn("watcher live market poll failed", slog.Any("error", err))
+			timer.Reset(w.untilNextPoll(w.client.clock.Now()))
+			continue
+		}
+
+		now := w.client.clock.Now()
+		cur := make(map[string]LiveMarketEntry, len(entries))
+		for _, e := range entries {
+			cur[e.SecurityID] = e
+		}
+		if prev != nil {
+			w.publish(ctx, diffLiveMarket(prev, entries, now))
+		}
+		prev = cur
+		timer.Reset(w.untilNextPoll(now))
+	}
+}
+
+// untilNextPoll returns the delay to the next multiple of the interval.
+func (w *Watcher) untilNextPoll(now time.Time) time.Duration {
+	return now.Truncate(w.interval).Add(w.interval).Sub(now)
+}
+
+func (w *Watcher) setPaused(paused bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.paused = paused
+}
+
+func (w *Watcher) closeAll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, s := range w.subs {
+		close(s.ch)
+	}
+	w.subs = nil
+	w.closed = true
+}
+
+// publish delivers events to every subscription whose filter matches.
+func (w *Watcher) publish(ctx context.Context, events []Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	w.mu.Lock()
+	needSectors := slices.ContainsFunc(w.subs, func(s *Subscription) bool { return len(s.filter.Sectors) > 0 })
+	w.mu.Unlock()
+	if needSectors {
+		// Resolve outside the lock; lookups may hit the network.
+		for _, e := range events {
+			w.sector(ctx, strings.ToUpper(e.Symbol))
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, s := range w.subs {
+		for _, e := range events {
+			if !w.matches(s.filter, e) {
+				continue
+			}
+			select {
+			case s.ch <- e:
+			default:
+				s.dropped.Add(1)
+			}
+		}
+	}
+}
+
+// matches reports whether e passes filter.
+func (w *Watcher) matches(filter WatchFilter, e Event) bool {
+	symbol := strings.ToUpper(e.Symbol)
+	if len(filter.Types) > 0 && !slices.Contains(filter.Types, e.Type) {
+		return false
+	}
+	if len(filter.Symbols) > 0 && !slices.Contains(filter.Symbols, symbol) {
+		return false
+	}
+	if len(filter.Sectors) > 0 && !slices.Contains(filter.Sectors, w.sectors[symbol]) {
+		return false
+	}
+	return true
+}
+
+// sector returns the upper-case sector of symbol, remembering successful lookups.
+// Only the Run goroutine writes w.sectors.
+func (w *Watcher) sector(ctx context.Context, symbol string) string {
+	w.mu.Lock()
+	sector, ok := w.sectors[symbol]
+	w.mu.Unlock()
+	if ok {
+		return sector
+	}
+
+	info, err := w.client.Symbols().Resolve(ctx, symbol)
+	if err != nil {
+		// Not remembered, so a later refresh of the index can still resolve it.
+		return ""
+	}
+	sector = strings.ToUpper(info.SectorName)
+	w.mu.Lock()
+	w.sectors[symbol] = sector
+	w.mu.Unlock()
+	return sector
+}
+
+func normalizeFilter(f WatchFilter) WatchFilter {
+	upper := func(values []string) []string {
+		out := make([]string, len(values))
+		for i, v := range values {
+			out[i] = strings.ToUpper(strings.TrimSpace(v))
+		}
+		return out
+	}
+	return WatchFilter{Symbols: upper(f.Symbols), Sectors: upper(f.Sectors), Types: slices.Clone(f.Types)}
+}
+
+// diffLiveMarket returns the events between prev and the entries of the next
+// snapshot, in snapshot order.
+func diffLiveMarket(prev map[string]LiveMarketEntry, cur []LiveMarketEntry, now time.Time) []Event {
+	var events []Event
+	for _, e := range cur {
+		emit := func(t EventType, previous *LiveMarketEntry) {
+			events = append(events, Event{Type: t, Symbol: e.Symbol, Previous: previous, Current: e, Time: now})
+		}
+
+		old, ok := prev[e.SecurityID]
+		if !ok {
+			emit(EventTrade, nil)
+			continue
+		}
+		p := &old
+		if !e.LastUpdatedDateTime.Equal(old.LastUpdatedDateTime.Time) || e.TotalTradeQuantity > old.TotalTradeQuantity {
+			emit(EventTrade, p)
+		}
+		if e.LastTradedPrice != old.LastTradedPrice {
+			emit(EventPriceChange, p)
+		}
+		if e.TotalTradeQuantity != old.TotalTradeQuantity {
+			emit(EventVolumeChange, p)
+		}
+		if e.HighPrice > old.HighPrice {
+			emit(EventNewHigh, p)
+		}
+		if e.LowPrice < old.LowPrice && e.LowPrice > 0 {
+			emit(EventNewLow, p)
+		}
+	}
+	return events
+}
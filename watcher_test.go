@@ -0,0 +1,225 @@
+package nepse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsbohara/go-nepse/nepsetest"
+)
+
+func TestDiffLiveMarket(t *testing.T) {
+	base := LiveMarketEntry{SecurityID: "131", Symbol: "NABIL", LastTradedPrice: 500, HighPrice: 505, LowPrice: 495, TotalTradeQuantity: 100, LastUpdatedDateTime: NewDateTime(nptTime(t, "2026-01-05 11:00"))}
+	prev := map[string]LiveMarketEntry{"131": base}
+
+	tests := []struct {
+		name   string
+		update func(e *LiveMarketEntry)
+		want   []EventType
+	}{
+		{"unchanged", func(e *LiveMarketEntry) {}, nil},
+		{"trade at same price", func(e *LiveMarketEntry) {
+			e.TotalTradeQuantity, e.LastUpdatedDateTime = 120, NewDateTime(nptTime(t, "2026-01-05 11:00").Add(5*time.Second))
+		}, []EventType{EventTrade, EventVolumeChange}},
+		{"new high", func(e *LiveMarketEntry) {
+			e.TotalTradeQuantity, e.LastTradedPrice, e.HighPrice = 110, 510, 510
+		}, []EventType{EventTrade, EventPriceChange, EventVolumeChange, EventNewHigh}},
+		{"new low", func(e *LiveMarketEntry) {
+			e.TotalTradeQuantity, e.LastTradedPrice, e.LowPrice = 110, 490, 490
+		}, []EventType{EventTrade, EventPriceChange, EventVolumeChange, EventNewLow}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur := base
+			tt.update(&cur)
+			var got []EventType
+			for _, e := range diffLiveMarket(prev, []LiveMarketEntry{cur}, time.Now()) {
+				if e.Previous == nil || e.Previous.LastTradedPrice != 500 {
+					t.Errorf("%s event has Previous %+v", e.Type, e.Previous)
+				}
+				got = append(got, e.Type)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	events := diffLiveMarket(prev, []LiveMarketEntry{base, {SecurityID: "2792", Symbol: "HDL"}}, time.Now())
+	if len(events) != 1 || events[0].Type != EventTrade || events[0].Previous != nil || events[0].Symbol != "HDL" {
+		t.Errorf("first trade of a security = %+v, want one trade event without Previous", events)
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	snapshots := [][]LiveMarketEntry{
+		{
+			{SecurityID: "131", Symbol: "NABIL", LastTradedPrice: 500, HighPrice: 505, LowPrice: 495, TotalTradeQuantity: 100},
+			{SecurityID: "141", Symbol: "NICA", LastTradedPrice: 800, HighPrice: 800, LowPrice: 800, TotalTradeQuantity: 50},
+		},
+		{
+			{SecurityID: "131", Symbol: "NABIL", LastTradedPrice: 510, HighPrice: 510, LowPrice: 495, TotalTradeQuantity: 150},
+			{SecurityID: "141", Symbol: "NICA", LastTradedPrice: 800, HighPrice: 800, LowPrice: 800, TotalTradeQuantity: 50},
+			{SecurityID: "2792", Symbol: "HDL", LastTradedPrice: 300, HighPrice: 300, LowPrice: 300, TotalTradeQuantity: 10},
+		},
+	}
+	var open atomic.Bool
+	open.Store(true)
+	var mu sync.Mutex
+	polls := 0
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/nepse-data/market-open":
+			status := MarketStatus{IsOpen: "CLOSE"}
+			if open.Load() {
+				status.IsOpen = "OPEN"
+			}
+			json.NewEncoder(w).Encode(status)
+		case "/api/nots/lives-market":
+			mu.Lock()
+			snapshot := snapshots[min(polls, len(snapshots)-1)]
+			polls++
+			mu.Unlock()
+			json.NewEncoder(w).Encode(snapshot)
+		case "/api/nots/security":
+			w.Write([]byte(`[{"id":131,"symbol":"NABIL"},{"id":141,"symbol":"NICA"},{"id":2792,"symbol":"HDL"}]`))
+		case "/api/nots/company/list":
+			w.Write([]byte(`[{"symbol":"NABIL","sectorName":"Commercial Banks"},{"symbol":"NICA","sectorName":"Commercial Banks"},{"symbol":"HDL","sectorName":"Hydro Power"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := newTestServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&Options{
+		BaseURL:     server.URL,
+		HTTPTimeout: 5 * time.Second,
+		MaxRetries:  0,
+		Config: &Config{
+			BaseURL:   server.URL,
+			Endpoints: DefaultEndpoints(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	watcher := NewWatcher(client, &WatcherOptions{Interval: 10 * time.Millisecond, ClosedInterval: 10 * time.Millisecond})
+	all := watcher.Subscribe(WatchFilter{}, 16)
+	nabilPrice := watcher.Subscribe(WatchFilter{Symbols: []string{"nabil"}, Types: []EventType{EventPriceChange}}, 16)
+	hydro := watcher.Subscribe(WatchFilter{Sectors: []string{"hydro power"}}, 16)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- watcher.Run(ctx) }()
+
+	receive := func(s *Subscription, n int) []Event {
+		t.Helper()
+		var events []Event
+		for len(events) < n {
+			select {
+			case e := <-s.C:
+				events = append(events, e)
+			case <-time.After(2 * time.Second):
+				t.Fatalf("received %d of %d events", len(events), n)
+			}
+		}
+		return events
+	}
+
+	var got []string
+	for _, e := range receive(all, 5) {
+		got = append(got, e.Symbol+":"+e.Type.String())
+	}
+	want := []string{"NABIL:trade", "NABIL:price_change", "NABIL:volume_change", "NABIL:new_high", "HDL:trade"}
+	if !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if e := receive(nabilPrice, 1)[0]; e.Type != EventPriceChange || e.Current.LastTradedPrice != 510 || e.Previous.LastTradedPrice != 500 {
+		t.Errorf("symbol subscription got %+v", e)
+	}
+	if e := receive(hydro, 1)[0]; e.Symbol != "HDL" {
+		t.Errorf("sector subscription got %+v", e)
+	}
+
+	open.Store(false)
+	deadline := time.Now().Add(2 * time.Second)
+	for !watcher.Paused() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !watcher.Paused() {
+		t.Error("watcher should pause while the market is closed")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Run returned %v, want context.Canceled", err)
+	}
+	if _, ok := <-all.C; ok {
+		t.Error("subscriptions should be closed when Run returns")
+	}
+	if all.Dropped() != 0 {
+		t.Errorf("dropped %d events", all.Dropped())
+	}
+}
+
+func TestWatcher_FollowsClock(t *testing.T) {
+	var polls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/authenticate/prove":
+			json.NewEncoder(w).Encode(tokenResponse())
+		case "/api/nots/nepse-data/market-open":
+			json.NewEncoder(w).Encode(MarketStatus{IsOpen: "OPEN"})
+		case "/api/nots/lives-market":
+			// Every poll moves the price, so every snapshot after the first has an event
+			n := polls.Add(1)
+			json.NewEncoder(w).Encode([]LiveMarketEntry{{SecurityID: "131", Symbol: "NABIL", LastTradedPrice: 500 + float64(n)}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	// Just before a whole minute, so the aligned poll is due almost at once
+	clock := nepsetest.NewClock(nptTime(t, "2026-01-05 11:00").Add(-10 * time.Millisecond))
+	client := newTestClient(t, handler, func(o *Options) { o.Clock = clock })
+
+	watcher := NewWatcher(client, &WatcherOptions{Interval: time.Minute})
+	sub := watcher.Subscribe(WatchFilter{Types: []EventType{EventPriceChange}}, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	receive := func() Event {
+		t.Helper()
+		select {
+		case e := <-sub.C:
+			return e
+		case <-time.After(2 * time.Second):
+			t.Fatal("no event; the watcher is not polling on the client clock")
+			return Event{}
+		}
+	}
+
+	if e := receive(); !e.Time.Equal(clock.Now()) {
+		t.Errorf("event time = %v, want the client clock %v", e.Time, clock.Now())
+	}
+	next := clock.Advance(time.Minute)
+	for {
+		if e := receive(); e.Time.Equal(next) {
+			break
+		}
+	}
+}